@@ -0,0 +1,219 @@
+// Package bookmarks stores annotated bookmarks on commits, one file per
+// repository, so a tour of the history survives restarts and can be shared.
+package bookmarks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Bookmark marks a commit, optionally with a note.
+type Bookmark struct {
+	Hash    string    `json:"hash"`
+	Note    string    `json:"note,omitempty"`
+	Subject string    `json:"subject,omitempty"` // for people reading the file
+	Created time.Time `json:"created"`
+}
+
+// file is the on-disk and export format.
+type file struct {
+	Version   int        `json:"version"`
+	Bookmarks []Bookmark `json:"bookmarks"`
+}
+
+const fileVersion = 1
+
+// Store holds the bookmarks of one repository, keyed by commit hash.
+type Store struct {
+	path   string
+	items  map[string]Bookmark
+	rev    int        // bumped on every change, see Rev
+	sorted []Bookmark // All's result, nil until asked for after a change
+}
+
+// Path returns where the bookmarks of the repository with the given git
+// directory are kept. The git directory is private to the clone, so nothing
+// ends up in the working tree.
+func Path(gitDir string) string {
+	return filepath.Join(gitDir, "gitcinema", "bookmarks.json")
+}
+
+// Open loads the bookmarks stored at path. A missing file is an empty store.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, items: map[string]Bookmark{}}
+	bms, err := read(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bms {
+		s.items[b.Hash] = b
+	}
+	return s, nil
+}
+
+// Get returns the bookmark on a commit.
+func (s *Store) Get(hash string) (Bookmark, bool) {
+	b, ok := s.items[hash]
+	return b, ok
+}
+
+// Has reports whether a commit is bookmarked.
+func (s *Store) Has(hash string) bool {
+	_, ok := s.items[hash]
+	return ok
+}
+
+// Len returns the number of bookmarks.
+func (s *Store) Len() int {
+	return len(s.items)
+}
+
+// All returns every bookmark, oldest first.
+func (s *Store) All() []Bookmark {
+	if s.sorted == nil {
+		s.sorted = make([]Bookmark, 0, len(s.items))
+		for _, b := range s.items {
+			s.sorted = append(s.sorted, b)
+		}
+		sort.Slice(s.sorted, func(i, j int) bool {
+			if !s.sorted[i].Created.Equal(s.sorted[j].Created) {
+				return s.sorted[i].Created.Before(s.sorted[j].Created)
+			}
+			return s.sorted[i].Hash < s.sorted[j].Hash
+		})
+	}
+	return slices.Clone(s.sorted)
+}
+
+// Rev returns a number that changes whenever the bookmarks do, so views
+// derived from them can be cached.
+func (s *Store) Rev() int {
+	return s.rev
+}
+
+// changed drops what was derived from the bookmarks before a change.
+func (s *Store) changed() {
+	s.rev++
+	s.sorted = nil
+}
+
+// Set adds or replaces a bookmark and saves the store. When saving fails the
+// store is left as it was.
+func (s *Store) Set(b Bookmark) error {
+	old, had := s.items[b.Hash]
+	if had && b.Created.IsZero() {
+		b.Created = old.Created
+	}
+	if b.Created.IsZero() {
+		b.Created = time.Now()
+	}
+	s.items[b.Hash] = b
+	s.changed()
+	if err := s.save(); err != nil {
+		if had {
+			s.items[b.Hash] = old
+		} else {
+			delete(s.items, b.Hash)
+		}
+		s.changed()
+		return err
+	}
+	return nil
+}
+
+// Delete removes the bookmark on a commit and saves the store. When saving
+// fails the bookmark is kept.
+func (s *Store) Delete(hash string) error {
+	old, ok := s.items[hash]
+	if !ok {
+		return nil
+	}
+	delete(s.items, hash)
+	s.changed()
+	if err := s.save(); err != nil {
+		s.items[hash] = old
+		s.changed()
+		return err
+	}
+	return nil
+}
+
+// Export writes every bookmark to path.
+func (s *Store) Export(path string) error {
+	return write(path, s.All())
+}
+
+// Import merges the bookmarks exported to path into the store, keeping the
+// note of a commit bookmarked on both sides from the imported file, and
+// returns how many bookmarks it read. When saving fails the store is left as
+// it was.
+func (s *Store) Import(path string) (int, error) {
+	bms, err := read(path)
+	if err != nil {
+		return 0, err
+	}
+	old := maps.Clone(s.items)
+	for _, b := range bms {
+		if b.Hash == "" {
+			continue
+		}
+		s.items[b.Hash] = b
+	}
+	s.changed()
+	if err := s.save(); err != nil {
+		s.items = old
+		s.changed()
+		return 0, err
+	}
+	return len(bms), nil
+}
+
+func (s *Store) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("saving bookmarks: %w", err)
+	}
+	return write(s.path, s.All())
+}
+
+func read(path string) ([]Bookmark, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading bookmarks: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("reading bookmarks from %s: %w", path, err)
+	}
+	if f.Version > fileVersion {
+		return nil, fmt.Errorf("reading bookmarks from %s: unsupported version %d", path, f.Version)
+	}
+	return f.Bookmarks, nil
+}
+
+// write saves bookmarks to path through a temporary file, so an interrupted
+// write never leaves a truncated file behind.
+func write(path string, bms []Bookmark) error {
+	data, err := json.MarshalIndent(file{Version: fileVersion, Bookmarks: bms}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("writing bookmarks: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing bookmarks: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing bookmarks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package bookmarks
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestImportKeepsStoreWhenSavingFails(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "bookmarks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set(Bookmark{Hash: "a", Note: "mine"}); err != nil {
+		t.Fatal(err)
+	}
+	export := filepath.Join(dir, "export.json")
+	if err := write(export, []Bookmark{{Hash: "a", Note: "theirs"}, {Hash: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	// A path below a regular file cannot be written.
+	s.path = filepath.Join(dir, "bookmarks.json", "bookmarks.json")
+
+	if n, err := s.Import(export); n != 0 || err == nil {
+		t.Fatalf("Import = %d, %v, want a failure", n, err)
+	}
+	if b, _ := s.Get("a"); s.Len() != 1 || b.Note != "mine" {
+		t.Fatalf("after a failed Import the store has %v", s.All())
+	}
+}
+
+func TestAllIsSortedAndRevTracksChanges(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "bookmarks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rev := s.Rev()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, b := range []Bookmark{{Hash: "c", Created: t0.Add(time.Hour)}, {Hash: "b", Created: t0}, {Hash: "a", Created: t0}} {
+		if err := s.Set(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.Rev() == rev {
+		t.Fatal("Rev did not change after Set")
+	}
+	all := s.All()
+	if len(all) != 3 || all[0].Hash != "a" || all[1].Hash != "b" || all[2].Hash != "c" {
+		t.Fatalf("All = %v, want a, b, c", all)
+	}
+	all[0].Hash = "x"
+	if s.All()[0].Hash != "a" {
+		t.Fatal("All exposes the store's slice")
+	}
+
+	rev = s.Rev()
+	if err := s.Delete("b"); err != nil {
+		t.Fatal(err)
+	}
+	if s.Rev() == rev || len(s.All()) != 2 {
+		t.Fatalf("after Delete: rev %d (was %d), All %v", s.Rev(), rev, s.All())
+	}
+}
@@ -0,0 +1,265 @@
+// Package config loads gitcinema's settings from JSON files: a user file in
+// the XDG config directory and an optional override file in the repository.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/meetsoni15/gitcinema/internal/query"
+)
+
+// RepoFile is the per-repository override file, looked up in the root of the
+// repository being played.
+const RepoFile = ".gitcinema.json"
+
+// Config is everything a config file can set. Unset fields keep gitcinema's
+// built-in defaults, and files loaded later override earlier ones field by
+// field.
+type Config struct {
+	Defaults Defaults            `json:"defaults"`
+	Cut      Cut                 `json:"cut"`
+	Pause    Pause               `json:"pause"`
+	Keys     map[string][]string `json:"keys,omitempty"` // action name → keys
+	Theme    string              `json:"theme,omitempty"`
+	Authors  Authors             `json:"authors"`
+	Layout   Layout              `json:"layout"`
+	Filters  map[string]string   `json:"filters,omitempty"` // name → filter query, used as @name
+}
+
+// Defaults are the startup values of settings that can also be changed while
+// playing.
+type Defaults struct {
+	Speed       *float64 `json:"speed,omitempty"`
+	Interval    string   `json:"interval,omitempty"`    // frame length at 1x, e.g. "800ms"
+	Max         *int     `json:"max,omitempty"`         // commits to load, 0 = all
+	Realtime    *bool    `json:"realtime,omitempty"`    // time-proportional playback
+	Compression string   `json:"compression,omitempty"` // history per second in real time, e.g. "1d"
+	Loop        *bool    `json:"loop,omitempty"`
+	Cut         *bool    `json:"cut,omitempty"`   // start with the director's cut on
+	Pause       *bool    `json:"pause,omitempty"` // start with pause-on-event on
+}
+
+// Cut holds the director's cut rules.
+type Cut struct {
+	Globs       []string `json:"globs,omitempty"`
+	MaxLines    *int     `json:"max_lines,omitempty"`
+	Merges      *bool    `json:"merges,omitempty"`
+	Bots        *bool    `json:"bots,omitempty"`
+	Messages    []string `json:"messages,omitempty"` // regular expressions
+	FastForward *bool    `json:"fast_forward,omitempty"`
+}
+
+// Pause holds the pause-on-event rules.
+type Pause struct {
+	Paths      []string `json:"paths,omitempty"`
+	NewAuthors *bool    `json:"new_authors,omitempty"`
+	Tags       *bool    `json:"tags,omitempty"`
+	MinLines   *int     `json:"min_lines,omitempty"`
+	Messages   []string `json:"messages,omitempty"` // regular expressions
+}
+
+// Authors customise how authors are shown. Palette picks a built-in palette
+// and Colors replaces it; Pins fix the look of individual people, keyed by
+// email or name.
+type Authors struct {
+	Palette string         `json:"palette,omitempty"`
+	Colors  []string       `json:"colors,omitempty"`
+	Pins    map[string]Pin `json:"pins,omitempty"`
+}
+
+// Pin overrides the display name, color or symbol of one author. Empty fields
+// keep the generated value.
+type Pin struct {
+	Name   string `json:"name,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Symbol string `json:"symbol,omitempty"`
+}
+
+// Layout arranges and sizes the panes.
+type Layout struct {
+	Mode  string `json:"mode,omitempty"`  // auto, split, stacked or tabbed
+	Split *int   `json:"split,omitempty"` // file pane share, percent of the width or, stacked, the height
+	Zen   *bool  `json:"zen,omitempty"`   // start in zen mode
+}
+
+// Split bounds, in percent of the terminal width.
+const (
+	MinSplit = 20
+	MaxSplit = 80
+)
+
+// UserPath returns the user config file, e.g. ~/.config/gitcinema/config.json.
+func UserPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitcinema", "config.json"), nil
+}
+
+// Load reads the given files in order, each overriding the ones before it.
+// Missing files are skipped; unknown fields are an error so typos do not go
+// unnoticed.
+func Load(paths ...string) (*Config, error) {
+	c := &Config{}
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(c); err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+	}
+	return c, nil
+}
+
+// Overlay applies every field set in o on top of c. Command-line flags are
+// collected into a Config and overlaid last.
+func (c *Config) Overlay(o *Config) {
+	data, _ := json.Marshal(o) // plain data, cannot fail
+	_ = json.Unmarshal(data, c)
+}
+
+// colorRE matches the colors lipgloss understands: hex or an ANSI number.
+var colorRE = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|#[0-9a-fA-F]{3}|[0-9]{1,3})$`)
+
+// Validate reports every value that is out of range or does not parse.
+// Names that only the UI knows, such as actions and themes, are checked when
+// the config is applied.
+func (c *Config) Validate() error {
+	var errs []error
+	bad := func(field, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	d := c.Defaults
+	if d.Speed != nil && *d.Speed <= 0 {
+		bad("defaults.speed", "must be positive, got %g", *d.Speed)
+	}
+	if d.Interval != "" {
+		if iv, err := time.ParseDuration(d.Interval); err != nil || iv <= 0 {
+			bad("defaults.interval", "want a positive duration like \"800ms\", got %q", d.Interval)
+		}
+	}
+	if d.Max != nil && *d.Max < 0 {
+		bad("defaults.max", "must not be negative, got %d", *d.Max)
+	}
+	if c.Cut.MaxLines != nil && *c.Cut.MaxLines < 0 {
+		bad("cut.max_lines", "must not be negative, got %d", *c.Cut.MaxLines)
+	}
+	if c.Pause.MinLines != nil && *c.Pause.MinLines < 0 {
+		bad("pause.min_lines", "must not be negative, got %d", *c.Pause.MinLines)
+	}
+	for i, m := range c.Cut.Messages {
+		if _, err := regexp.Compile(m); err != nil {
+			bad(fmt.Sprintf("cut.messages[%d]", i), "%v", err)
+		}
+	}
+	for i, m := range c.Pause.Messages {
+		if _, err := regexp.Compile(m); err != nil {
+			bad(fmt.Sprintf("pause.messages[%d]", i), "%v", err)
+		}
+	}
+	for action, keys := range c.Keys {
+		for _, k := range keys {
+			if k == "" {
+				bad("keys."+action, "empty key")
+			}
+		}
+	}
+	for i, col := range c.Authors.Colors {
+		if !colorRE.MatchString(col) {
+			bad(fmt.Sprintf("authors.colors[%d]", i), "want #rrggbb or an ANSI number, got %q", col)
+		}
+	}
+	for who, p := range c.Authors.Pins {
+		if p.Color != "" && !colorRE.MatchString(p.Color) {
+			bad("authors.pins."+who+".color", "want #rrggbb or an ANSI number, got %q", p.Color)
+		}
+	}
+	if s := c.Layout.Split; s != nil && (*s < MinSplit || *s > MaxSplit) {
+		bad("layout.split", "must be between %d and %d, got %d", MinSplit, MaxSplit, *s)
+	}
+	for name, q := range c.Filters {
+		if !query.ValidName(name) {
+			bad("filters."+name, "names may only use letters, digits, - and _")
+		} else if _, err := query.Parse(q, c.Filters); err != nil {
+			bad("filters."+name, "%s", strings.ReplaceAll(err.Error(), "\n", "; "))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ThemeFile is a user theme: a built-in theme with some of its colors
+// replaced, keyed by name, e.g. {"base": "dark", "colors": {"accent": "#ff9e64"}}.
+type ThemeFile struct {
+	Base   string            `json:"base,omitempty"`
+	Colors map[string]string `json:"colors,omitempty"`
+}
+
+// ThemePath resolves a theme that is not built in: a path to a JSON file, or
+// the name of a file in the themes directory next to the user config, e.g.
+// ~/.config/gitcinema/themes/NAME.json.
+func ThemePath(ref string) string {
+	if strings.ContainsRune(ref, filepath.Separator) || strings.ContainsRune(ref, '/') ||
+		strings.HasSuffix(ref, ".json") {
+		return ref
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gitcinema", "themes", ref+".json")
+}
+
+// LoadTheme reads and validates a theme file.
+func LoadTheme(path string) (*ThemeFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t := &ThemeFile{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(t); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	var errs []error
+	for name, col := range t.Colors {
+		if !colorRE.MatchString(col) {
+			errs = append(errs, fmt.Errorf("%s: colors.%s: want #rrggbb or an ANSI number, got %q", path, name, col))
+		}
+	}
+	return t, errors.Join(errs...)
+}
+
+// IntervalDuration returns the configured frame interval, or 0 when unset.
+func (d Defaults) IntervalDuration() time.Duration {
+	iv, _ := time.ParseDuration(d.Interval)
+	return iv
+}
+
+// Regexps compiles a validated list of regular expressions.
+func Regexps(patterns []string) []*regexp.Regexp {
+	var out []*regexp.Regexp
+	for _, p := range patterns {
+		if re, err := regexp.Compile(p); err == nil {
+			out = append(out, re)
+		}
+	}
+	return out
+}
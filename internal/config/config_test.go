@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, data string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLoadLayersFiles(t *testing.T) {
+	dir := t.TempDir()
+	user := writeFile(t, dir, "user.json", `{
+		"defaults": {"speed": 2, "max": 1000},
+		"cut": {"globs": ["go.sum"]},
+		"keys": {"play": ["p"], "quit": ["Q"]}
+	}`)
+	repo := writeFile(t, dir, "repo.json", `{
+		"defaults": {"speed": 4},
+		"cut": {"globs": ["*.lock"]},
+		"keys": {"play": ["space"]}
+	}`)
+
+	c, err := Load(user, filepath.Join(dir, "missing.json"), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *c.Defaults.Speed != 4 {
+		t.Errorf("speed = %g, want the repo file's 4", *c.Defaults.Speed)
+	}
+	if *c.Defaults.Max != 1000 {
+		t.Errorf("max = %d, want the user file's 1000", *c.Defaults.Max)
+	}
+	if g := c.Cut.Globs; len(g) != 1 || g[0] != "*.lock" {
+		t.Errorf("cut.globs = %v, want the repo file's list", g)
+	}
+	if k := c.Keys; len(k["play"]) != 1 || k["play"][0] != "space" || k["quit"][0] != "Q" {
+		t.Errorf("keys = %v, want play from the repo file and quit from the user file", k)
+	}
+
+	zero, speed := 0, 8.0
+	c.Overlay(&Config{Defaults: Defaults{Max: &zero, Speed: &speed}})
+	if *c.Defaults.Max != 0 || *c.Defaults.Speed != 8 {
+		t.Errorf("after overlay max %d, speed %g, want the flags' 0 and 8", *c.Defaults.Max, *c.Defaults.Speed)
+	}
+	if g := c.Cut.Globs; len(g) != 1 || g[0] != "*.lock" {
+		t.Errorf("overlay without globs changed cut.globs to %v", g)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"typo.json":   `{"defaults": {"sped": 2}}`,
+		"broken.json": `{"defaults": `,
+	} {
+		p := writeFile(t, dir, name, data)
+		if _, err := Load(p); err == nil || !strings.Contains(err.Error(), p) {
+			t.Errorf("Load(%s) error = %v, want one naming the file", name, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		json string
+		want string // part of the error, "" = valid
+	}{
+		{`{}`, ""},
+		{`{"defaults": {"max": 0}}`, ""},
+		{`{"defaults": {"max": 500, "speed": 0.5, "interval": "600ms"}}`, ""},
+		{`{"defaults": {"max": -1}}`, "defaults.max: must not be negative"},
+		{`{"defaults": {"speed": 0}}`, "defaults.speed"},
+		{`{"defaults": {"interval": "fast"}}`, "defaults.interval"},
+		{`{"cut": {"max_lines": -1}}`, "cut.max_lines"},
+		{`{"pause": {"messages": ["("]}}`, "pause.messages[0]"},
+		{`{"keys": {"play": [""]}}`, "keys.play: empty key"},
+		{`{"authors": {"colors": ["red"]}}`, "authors.colors[0]"},
+		{`{"authors": {"pins": {"a@x": {"color": "#12"}}}}`, "authors.pins.a@x.color"},
+		{`{"layout": {"split": 90}}`, "layout.split"},
+		{`{"filters": {"big": "size:>200", "mine": "@big author:me"}}`, ""},
+		{`{"filters": {"a b": "x"}}`, "filters.a b"},
+		{`{"filters": {"loop": "@loop"}}`, "refers to itself"},
+	}
+	dir := t.TempDir()
+	for i, tt := range tests {
+		c, err := Load(writeFile(t, dir, "c.json", tt.json))
+		if err != nil {
+			t.Fatalf("%d: Load: %v", i, err)
+		}
+		err = c.Validate()
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("Validate(%s) = %v, want nil", tt.json, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("Validate(%s) = %v, want an error containing %q", tt.json, err, tt.want)
+		}
+	}
+}
@@ -1,30 +1,9 @@
 package git
 
 import (
-	"hash/fnv"
-
 	"github.com/charmbracelet/lipgloss"
 )
 
-// authorSymbols is the ordered set of symbols assigned to each new author.
-var authorSymbols = []string{"●", "◆", "▲", "■", "★", "✦", "◉", "⬟", "◈", "⬡", "◇", "▼"}
-
-// authorPalette is a curated set of vibrant, distinct colors for authors.
-var authorPalette = []lipgloss.Color{
-	"#7aa2f7", // blue
-	"#9ece6a", // green
-	"#f7768e", // red/pink
-	"#e0af68", // yellow
-	"#bb9af7", // purple
-	"#2ac3de", // cyan
-	"#ff9e64", // orange
-	"#73daca", // teal
-	"#b4f9f8", // light cyan
-	"#c0caf5", // lavender
-	"#f7c67f", // light orange
-	"#db4b4b", // deep red
-}
-
 // Author represents a unique contributor with display attributes.
 type Author struct {
 	Name   string
@@ -44,15 +23,39 @@ func (a *Author) Tag() string {
 		Render(a.Symbol + " " + a.Name)
 }
 
-// Registry tracks all unique authors encountered in the history.
+// Identity overrides how one author is shown. Empty fields keep the
+// generated value.
+type Identity struct {
+	Name   string
+	Color  lipgloss.Color
+	Symbol string
+}
+
+// Identities customise author display: Colors replaces the default palette
+// (see Palette) and Pins fix individual authors, keyed by email or name.
+type Identities struct {
+	Colors []lipgloss.Color
+	Pins   map[string]Identity
+}
+
+// Registry tracks all unique authors encountered in the history. No two
+// authors share both color and symbol, unless pinned that way.
 type Registry struct {
 	authors map[string]*Author // keyed by email
 	order   []string           // insertion order (emails)
+	ids     Identities
+	used    map[identityKey]bool // color and symbol pairs taken
+	next    int                  // index of the next generated identity
+}
+
+type identityKey struct {
+	color  lipgloss.Color
+	symbol string
 }
 
 // NewRegistry creates a fresh author registry.
 func NewRegistry() *Registry {
-	return &Registry{authors: make(map[string]*Author)}
+	return &Registry{authors: make(map[string]*Author), used: make(map[identityKey]bool)}
 }
 
 // Register ensures an author is tracked, assigning color+symbol on first encounter.
@@ -60,18 +63,48 @@ func (r *Registry) Register(name, email string) *Author {
 	if a, ok := r.authors[email]; ok {
 		return a
 	}
-	idx := len(r.order)
-	a := &Author{
-		Name:   name,
-		Email:  email,
-		Color:  paletteColorForEmail(email, idx),
-		Symbol: symbolForIndex(idx),
+	a := &Author{Name: name, Email: email}
+	id := r.pinFor(a)
+	if id.Name != "" {
+		a.Name = id.Name
 	}
+	a.Color, a.Symbol = r.assign(id)
+	r.used[identityKey{a.Color, a.Symbol}] = true
 	r.authors[email] = a
 	r.order = append(r.order, email)
 	return a
 }
 
+// assign picks an author's color and symbol: the pinned ones where set, the
+// rest from the next generated identity whose pair is still free.
+func (r *Registry) assign(id Identity) (lipgloss.Color, string) {
+	if id.Color != "" && id.Symbol != "" {
+		return id.Color, id.Symbol
+	}
+	palette := r.ids.Colors
+	if len(palette) == 0 {
+		palette = authorPalette
+	}
+	// Each taken pair blocks at most one generated identity, but a pinned
+	// color or symbol repeats, so give up after trying every symbol as well.
+	var c lipgloss.Color
+	var s string
+	for tries := len(r.used) + len(authorSymbols) + 1; tries > 0; tries-- {
+		c, s = generatedIdentity(r.next, palette)
+		r.next++
+		if id.Color != "" {
+			c = id.Color
+		}
+		if id.Symbol != "" {
+			s = id.Symbol
+		}
+		if !r.used[identityKey{c, s}] {
+			break
+		}
+	}
+	return c, s
+}
+
 // Get returns an author by email, or nil.
 func (r *Registry) Get(email string) *Author {
 	return r.authors[email]
@@ -91,26 +124,28 @@ func (r *Registry) Len() int {
 	return len(r.order)
 }
 
-// BuildRegistry walks the full commit history and registers all authors.
-func BuildRegistry(commits []Commit) *Registry {
+// pinFor returns the identity pinned to the author's email or, failing that,
+// their name.
+func (r *Registry) pinFor(a *Author) Identity {
+	if id, ok := r.ids.Pins[a.Email]; ok {
+		return id
+	}
+	return r.ids.Pins[a.Name]
+}
+
+// BuildRegistry walks the full commit history and registers all authors,
+// applying the given display overrides.
+func BuildRegistry(commits []Commit, ids Identities) *Registry {
 	r := NewRegistry()
+	r.ids = ids
+	// Fully pinned pairs are reserved before anyone else is assigned.
+	for _, id := range ids.Pins {
+		if id.Color != "" && id.Symbol != "" {
+			r.used[identityKey{id.Color, id.Symbol}] = true
+		}
+	}
 	for _, c := range commits {
 		r.Register(c.Author, c.Email)
 	}
 	return r
 }
-
-// paletteColorForEmail picks a color — first by stable index, with FNV fallback.
-func paletteColorForEmail(email string, idx int) lipgloss.Color {
-	if idx < len(authorPalette) {
-		return authorPalette[idx]
-	}
-	// Deterministic fallback via hash
-	h := fnv.New32a()
-	h.Write([]byte(email))
-	return authorPalette[h.Sum32()%uint32(len(authorPalette))]
-}
-
-func symbolForIndex(idx int) string {
-	return authorSymbols[idx%len(authorSymbols)]
-}
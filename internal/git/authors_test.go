@@ -0,0 +1,73 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestGeneratedIdentitiesAreDistinct(t *testing.T) {
+	for _, palette := range [][]lipgloss.Color{authorPalette, {"#111111", "#222222", "#333333"}} {
+		seen := map[identityKey]int{}
+		for n := 0; n < len(palette)*len(authorSymbols)+500; n++ {
+			c, s := generatedIdentity(n, palette)
+			if prev, ok := seen[identityKey{c, s}]; ok {
+				t.Fatalf("palette of %d: identities %d and %d are both %s %s", len(palette), prev, n, c, s)
+			}
+			seen[identityKey{c, s}] = n
+		}
+	}
+}
+
+func authorCommits(n int) []Commit {
+	commits := make([]Commit, n)
+	for i := range commits {
+		commits[i] = Commit{Author: fmt.Sprintf("dev%d", i), Email: fmt.Sprintf("dev%d@example.com", i)}
+	}
+	return commits
+}
+
+func distinctPairs(t *testing.T, r *Registry) {
+	t.Helper()
+	seen := map[identityKey]string{}
+	for _, a := range r.All() {
+		k := identityKey{a.Color, a.Symbol}
+		if prev, ok := seen[k]; ok {
+			t.Fatalf("%s and %s are both %s %s", prev, a.Email, a.Color, a.Symbol)
+		}
+		seen[k] = a.Email
+	}
+}
+
+func TestBuildRegistryGivesEveryAuthorADistinctIdentity(t *testing.T) {
+	commits := authorCommits(400)
+	commits = append(commits, commits[3]) // a repeat commit keeps its identity
+	r := BuildRegistry(commits, Identities{})
+	if r.Len() != 400 {
+		t.Fatalf("Len = %d, want 400", r.Len())
+	}
+	distinctPairs(t, r)
+}
+
+func TestBuildRegistryPins(t *testing.T) {
+	first, firstSym := generatedIdentity(0, authorPalette)
+	ids := Identities{Pins: map[string]Identity{
+		// A fully pinned pair is reserved even though dev9 registers late.
+		"dev9@example.com": {Color: first, Symbol: firstSym},
+		// Pinned by name, with only the color fixed.
+		"dev1": {Name: "Dev One", Color: first},
+	}}
+	r := BuildRegistry(authorCommits(40), ids)
+	distinctPairs(t, r)
+
+	if a := r.Get("dev9@example.com"); a.Color != first || a.Symbol != firstSym {
+		t.Errorf("dev9 = %s %s, want the pinned %s %s", a.Color, a.Symbol, first, firstSym)
+	}
+	if a := r.Get("dev0@example.com"); a.Color == first && a.Symbol == firstSym {
+		t.Error("dev0 got dev9's pinned identity")
+	}
+	if a := r.Get("dev1@example.com"); a.Name != "Dev One" || a.Color != first {
+		t.Errorf("dev1 = %q %s, want the pinned name and color", a.Name, a.Color)
+	}
+}
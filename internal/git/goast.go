@@ -0,0 +1,205 @@
+package git
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// FuncRef identifies a function or method declared in a Go file.
+type FuncRef struct {
+	Path string // file path relative to the repository root
+	Recv string // receiver type as written ("*Model", "Registry"), empty for funcs
+	Name string
+	Line int // declaration line at the commit it was listed from
+}
+
+// String returns the Go-style name, e.g. "(*Model).Update" or "LoadDiff".
+func (f FuncRef) String() string {
+	if f.Recv == "" {
+		return f.Name
+	}
+	return "(" + f.Recv + ")." + f.Name
+}
+
+// sameDecl reports whether two refs name the same declaration, ignoring
+// whether the receiver is a pointer.
+func (f FuncRef) sameDecl(g FuncRef) bool {
+	return f.Name == g.Name &&
+		strings.TrimPrefix(f.Recv, "*") == strings.TrimPrefix(g.Recv, "*")
+}
+
+// GoFuncsAt lists every function and method declared in the .go files of the
+// given commit, ordered by path then line.
+func GoFuncsAt(dir, hash string) ([]FuncRef, error) {
+	paths, err := ListFiles(dir, hash)
+	if err != nil {
+		return nil, err
+	}
+	var goPaths []string
+	for _, p := range paths {
+		if strings.HasSuffix(p, ".go") {
+			goPaths = append(goPaths, p)
+		}
+	}
+	files, err := ReadFiles(dir, hash, goPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	var funcs []FuncRef
+	for _, p := range goPaths {
+		src, ok := files[p]
+		if !ok {
+			continue
+		}
+		fset := token.NewFileSet()
+		f, _ := parser.ParseFile(fset, p, src, parser.SkipObjectResolution)
+		if f == nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			funcs = append(funcs, FuncRef{
+				Path: p,
+				Recv: recvString(fd),
+				Name: fd.Name.Name,
+				Line: fset.Position(fd.Pos()).Line,
+			})
+		}
+	}
+	sort.SliceStable(funcs, func(i, j int) bool {
+		if funcs[i].Path != funcs[j].Path {
+			return funcs[i].Path < funcs[j].Path
+		}
+		return funcs[i].Line < funcs[j].Line
+	})
+	return funcs, nil
+}
+
+// FuncHistory returns the hashes of commits on branch that changed the body
+// of fn, including the commit that introduced it and the one that removed it.
+// The declaration is resolved by name in every version of the file, so moving
+// it around the file does not count as a change; file renames are followed.
+func FuncHistory(dir, branch string, fn FuncRef) (map[string]bool, error) {
+	args := []string{"-C", dir, "log", "--follow", "--format=%H", "--name-only"}
+	if branch != "" {
+		args = append(args, branch)
+	}
+	args = append(args, "--", fn.Path)
+
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log --follow: %w", err)
+	}
+
+	// Output is newest first: a hash line followed by the path at that commit.
+	type version struct{ hash, path string }
+	var versions []version
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case isHash(line):
+			versions = append(versions, version{hash: line, path: fn.Path})
+		case len(versions) > 0:
+			versions[len(versions)-1].path = line
+		}
+	}
+
+	specs := make([]string, len(versions))
+	for i, v := range versions {
+		specs[i] = v.hash + ":" + v.path
+	}
+	blobs, err := readBlobs(dir, specs)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := map[string]bool{}
+	prev, prevFound := "", false
+	for i := len(versions) - 1; i >= 0; i-- {
+		body, found := "", false
+		if src, ok := blobs[specs[i]]; ok {
+			body, found = funcBody(versions[i].path, src, fn)
+		}
+		if found != prevFound || body != prev {
+			changed[versions[i].hash] = true
+		}
+		prev, prevFound = body, found
+	}
+	return changed, nil
+}
+
+// funcBody returns the source text of fn's body within src.
+func funcBody(path string, src []byte, fn FuncRef) (string, bool) {
+	fset := token.NewFileSet()
+	f, _ := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if f == nil {
+		return "", false
+	}
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+		if !fn.sameDecl(FuncRef{Recv: recvString(fd), Name: fd.Name.Name}) {
+			continue
+		}
+		start := fset.Position(fd.Body.Lbrace).Offset
+		end := fset.Position(fd.Body.Rbrace).Offset + 1
+		if start < 0 || end > len(src) || start >= end {
+			return "", false
+		}
+		return string(src[start:end]), true
+	}
+	return "", false
+}
+
+// recvString renders a method receiver type, e.g. "*Model" or "List[T]".
+func recvString(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return ""
+	}
+	var render func(ast.Expr) string
+	render = func(e ast.Expr) string {
+		switch t := e.(type) {
+		case *ast.Ident:
+			return t.Name
+		case *ast.StarExpr:
+			return "*" + render(t.X)
+		case *ast.IndexExpr:
+			return render(t.X) + "[" + render(t.Index) + "]"
+		case *ast.IndexListExpr:
+			var params []string
+			for _, idx := range t.Indices {
+				params = append(params, render(idx))
+			}
+			return render(t.X) + "[" + strings.Join(params, ", ") + "]"
+		case *ast.ParenExpr:
+			return render(t.X)
+		}
+		return "?"
+	}
+	return render(fd.Recv.List[0].Type)
+}
+
+// isHash reports whether s looks like a full hex object name.
+func isHash(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
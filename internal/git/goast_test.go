@@ -0,0 +1,77 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoFuncsAt(t *testing.T) {
+	r := newTestRepo(t)
+	c := r.commit("dev@example.com", "add", map[string]string{
+		"b.go":      "package p\n\nfunc B() {}\n",
+		"a.go":      "package p\n\ntype T struct{}\n\nfunc (t *T) M() {}\n\nfunc A() {}\n",
+		"README.md": "p\n",
+	})
+	funcs, err := GoFuncsAt(r.dir, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FuncRef{
+		{Path: "a.go", Recv: "*T", Name: "M", Line: 5},
+		{Path: "a.go", Name: "A", Line: 7},
+		{Path: "b.go", Name: "B", Line: 3},
+	}
+	if !reflect.DeepEqual(funcs, want) {
+		t.Fatalf("GoFuncsAt = %v, want %v", funcs, want)
+	}
+	if s := funcs[0].String(); s != "(*T).M" {
+		t.Errorf("String = %q, want (*T).M", s)
+	}
+}
+
+func TestFuncHistory(t *testing.T) {
+	r := newTestRepo(t)
+	added := r.commit("dev@example.com", "add F", map[string]string{
+		"a.go": "package p\n\nfunc F() int { return 1 }\n\nfunc G() int { return 1 }\n",
+	})
+	r.commit("dev@example.com", "change G", map[string]string{
+		"a.go": "package p\n\nfunc F() int { return 1 }\n\nfunc G() int { return 2 }\n",
+	})
+	r.commit("dev@example.com", "move F", map[string]string{
+		"a.go": "package p\n\nfunc G() int { return 2 }\n\nfunc F() int { return 1 }\n",
+	})
+	changed := r.commit("dev@example.com", "change F", map[string]string{
+		"a.go": "package p\n\nfunc G() int { return 2 }\n\nfunc F() int { return 3 }\n",
+	})
+	r.git("mv", "a.go", "b.go")
+	r.commit("dev@example.com", "rename", nil)
+	removed := r.commit("dev@example.com", "remove F", map[string]string{
+		"b.go": "package p\n\nfunc G() int { return 2 }\n",
+	})
+
+	got, err := FuncHistory(r.dir, "main", FuncRef{Path: "b.go", Name: "F"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{added: true, changed: true, removed: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FuncHistory = %v, want the commits adding, changing and removing F: %v", got, want)
+	}
+}
+
+func TestFuncHistoryIgnoresPointerReceiver(t *testing.T) {
+	r := newTestRepo(t)
+	added := r.commit("dev@example.com", "add", map[string]string{
+		"a.go": "package p\n\ntype T struct{}\n\nfunc (T) M() {}\n",
+	})
+	changed := r.commit("dev@example.com", "pointer receiver", map[string]string{
+		"a.go": "package p\n\ntype T struct{}\n\nfunc (*T) M() { _ = 1 }\n",
+	})
+	got, err := FuncHistory(r.dir, "main", FuncRef{Path: "a.go", Recv: "*T", Name: "M"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{added: true, changed: true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("FuncHistory = %v, want %v", got, want)
+	}
+}
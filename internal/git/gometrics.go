@@ -0,0 +1,271 @@
+package git
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strings"
+)
+
+// PackageMetrics holds semantic counts for one Go package directory.
+// Test files only contribute to Tests.
+type PackageMetrics struct {
+	Dir           string
+	Name          string
+	Exported      int // exported top-level identifiers, including methods
+	ExportedFuncs int // exported functions and methods
+	Funcs         int // all functions and methods
+	Types         int
+	Tests         int // Test, Benchmark, Fuzz and Example functions
+}
+
+func (p *PackageMetrics) add(o PackageMetrics) {
+	p.Exported += o.Exported
+	p.ExportedFuncs += o.ExportedFuncs
+	p.Funcs += o.Funcs
+	p.Types += o.Types
+	p.Tests += o.Tests
+}
+
+// GoMetrics holds semantic Go stats for the whole tree at one commit.
+type GoMetrics struct {
+	Packages map[string]*PackageMetrics // keyed by directory
+	Totals   PackageMetrics
+}
+
+// MetricChange is a change in one counter of one package between two frames.
+type MetricChange struct {
+	Dir   string // package directory, "." for the repository root
+	Label string // e.g. "exported funcs"
+	Delta int
+}
+
+// String renders the change as "+3 exported funcs in internal/git".
+func (c MetricChange) String() string {
+	return fmt.Sprintf("%+d %s in %s", c.Delta, c.Label, c.Dir)
+}
+
+// Cache sizes. Commit results cover a stretch of playback around the
+// cursor; file results are small and let consecutive frames reparse only the
+// files that changed.
+const (
+	commitCacheSize = 64
+	blobCacheSize   = 8192
+)
+
+var (
+	metricsCache = newLRU[string, *GoMetrics](commitCacheSize)   // keyed by commit hash
+	fileMetrics  = newLRU[string, PackageMetrics](blobCacheSize) // keyed by blob object name
+)
+
+// LoadGoMetrics parses every .go file at the given commit and returns
+// per-package counts. Results are cached per commit, and per file contents so
+// that consecutive frames only parse the files that changed. Like the go
+// tool, it skips vendor and testdata directories and those starting with "."
+// or "_".
+func LoadGoMetrics(dir, hash string) (*GoMetrics, error) {
+	if cached, ok := metricsCache.get(hash); ok {
+		return cached, nil
+	}
+
+	entries, err := ListTree(dir, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var goFiles []TreeEntry
+	files := map[string]PackageMetrics{} // by blob
+	var missing []string
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Path, ".go") || ignoredGoDir(path.Dir(e.Path)) {
+			continue
+		}
+		goFiles = append(goFiles, e)
+		if fm, ok := fileMetrics.get(e.Blob); ok {
+			files[e.Blob] = fm
+		} else {
+			missing = append(missing, e.Blob)
+		}
+	}
+
+	blobs, err := readBlobs(dir, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &GoMetrics{Packages: map[string]*PackageMetrics{}}
+	for _, e := range goFiles {
+		fm, ok := files[e.Blob]
+		if !ok {
+			src, found := blobs[e.Blob]
+			if !found {
+				continue
+			}
+			fm = parseFileMetrics(e.Path, src)
+			files[e.Blob] = fm
+			fileMetrics.put(e.Blob, fm)
+		}
+		if fm.Name == "" {
+			continue // unparsable
+		}
+
+		d := path.Dir(e.Path)
+		pkg, ok := g.Packages[d]
+		if !ok {
+			pkg = &PackageMetrics{Dir: d}
+			g.Packages[d] = pkg
+		}
+		if !strings.HasSuffix(e.Path, "_test.go") {
+			pkg.Name = fm.Name
+		}
+		pkg.add(fm)
+		g.Totals.add(fm)
+	}
+	// Directories holding only tests are not packages of their own.
+	for d, pkg := range g.Packages {
+		if pkg.Name == "" {
+			delete(g.Packages, d)
+			g.Totals.Exported -= pkg.Exported
+			g.Totals.ExportedFuncs -= pkg.ExportedFuncs
+			g.Totals.Funcs -= pkg.Funcs
+			g.Totals.Types -= pkg.Types
+			g.Totals.Tests -= pkg.Tests
+		}
+	}
+	metricsCache.put(hash, g)
+	return g, nil
+}
+
+// ignoredGoDir reports whether the go tool ignores a directory: vendor and
+// testdata directories, those starting with "." or "_", and everything below
+// them.
+func ignoredGoDir(dir string) bool {
+	if dir == "." {
+		return false
+	}
+	for _, part := range strings.Split(dir, "/") {
+		if part == "vendor" || part == "testdata" || strings.HasPrefix(part, ".") || strings.HasPrefix(part, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+// Changes lists per-package counter changes from prev to g, largest first.
+// A nil prev is treated as an empty tree.
+func (g *GoMetrics) Changes(prev *GoMetrics) []MetricChange {
+	if prev == nil {
+		prev = &GoMetrics{}
+	}
+	dirs := map[string]bool{}
+	for d := range g.Packages {
+		dirs[d] = true
+	}
+	for d := range prev.Packages {
+		dirs[d] = true
+	}
+
+	var out []MetricChange
+	for d := range dirs {
+		var cur, old PackageMetrics
+		if p := g.Packages[d]; p != nil {
+			cur = *p
+		}
+		if p := prev.Packages[d]; p != nil {
+			old = *p
+		}
+		for _, f := range []struct {
+			label    string
+			cur, old int
+		}{
+			{"exported funcs", cur.ExportedFuncs, old.ExportedFuncs},
+			{"exported identifiers", cur.Exported, old.Exported},
+			{"funcs", cur.Funcs, old.Funcs},
+			{"types", cur.Types, old.Types},
+			{"tests", cur.Tests, old.Tests},
+		} {
+			if f.cur != f.old {
+				out = append(out, MetricChange{Dir: d, Label: f.label, Delta: f.cur - f.old})
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ai, aj := abs(out[i].Delta), abs(out[j].Delta)
+		if ai != aj {
+			return ai > aj
+		}
+		if out[i].Dir != out[j].Dir {
+			return out[i].Dir < out[j].Dir
+		}
+		return out[i].Label < out[j].Label
+	})
+	return out
+}
+
+// parseFileMetrics counts the declarations of a single Go file. The returned
+// Name is empty when the file cannot be parsed at all.
+func parseFileMetrics(filename string, src []byte) PackageMetrics {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if f == nil || (err != nil && len(f.Decls) == 0) {
+		return PackageMetrics{}
+	}
+	fm := PackageMetrics{Name: f.Name.Name}
+
+	if strings.HasSuffix(filename, "_test.go") {
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Recv == nil && isTestFunc(fd.Name.Name) {
+				fm.Tests++
+			}
+		}
+		return fm
+	}
+
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			fm.Funcs++
+			if d.Name.IsExported() {
+				fm.Exported++
+				fm.ExportedFuncs++
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					fm.Types++
+					if s.Name.IsExported() {
+						fm.Exported++
+					}
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						if n.IsExported() {
+							fm.Exported++
+						}
+					}
+				}
+			}
+		}
+	}
+	return fm
+}
+
+// isTestFunc reports whether name is one `go test` would run.
+func isTestFunc(name string) bool {
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,66 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoMetricsChanges(t *testing.T) {
+	prev := &GoMetrics{Packages: map[string]*PackageMetrics{
+		".":       {Dir: ".", Funcs: 2, ExportedFuncs: 1, Exported: 1},
+		"old/pkg": {Dir: "old/pkg", Types: 1},
+	}}
+	cur := &GoMetrics{Packages: map[string]*PackageMetrics{
+		".":       {Dir: ".", Funcs: 5, ExportedFuncs: 1, Exported: 1, Tests: 1},
+		"new/pkg": {Dir: "new/pkg", Funcs: 2},
+	}}
+	want := []MetricChange{ // largest first, then by directory and label
+		{Dir: ".", Label: "funcs", Delta: 3},
+		{Dir: "new/pkg", Label: "funcs", Delta: 2},
+		{Dir: ".", Label: "tests", Delta: 1},
+		{Dir: "old/pkg", Label: "types", Delta: -1},
+	}
+	got := cur.Changes(prev)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Changes = %v, want %v", got, want)
+	}
+	if s := got[3].String(); s != "-1 types in old/pkg" {
+		t.Errorf("String = %q", s)
+	}
+
+	if got := cur.Changes(cur); len(got) != 0 {
+		t.Errorf("Changes against itself = %v, want none", got)
+	}
+	if got := (&GoMetrics{Packages: map[string]*PackageMetrics{"a": {Types: 2}}}).Changes(nil); !reflect.DeepEqual(got,
+		[]MetricChange{{Dir: "a", Label: "types", Delta: 2}}) {
+		t.Errorf("Changes against nil = %v, want everything as added", got)
+	}
+}
+
+func TestLoadGoMetrics(t *testing.T) {
+	r := newTestRepo(t)
+	c := r.commit("dev@example.com", "add", map[string]string{
+		"go.mod":                  "module example.com/m\n",
+		"main.go":                 "package main\n\nfunc main() {}\n",
+		"lib/lib.go":              "package lib\n\ntype T struct{}\n\nconst Max = 1\n\nfunc (T) Do() {}\n\nfunc help() {}\n",
+		"lib/lib_test.go":         "package lib\n\nimport \"testing\"\n\nfunc TestDo(t *testing.T) {}\n\nfunc helper() {}\n",
+		"vendor/x/x.go":           "package x\n\nfunc X() {}\n",
+		"lib/testdata/gen/gen.go": "package gen\n\nfunc Gen() {}\n",
+		"_tools/t.go":             "package tools\n\nfunc T() {}\n",
+	})
+	m, err := LoadGoMetrics(r.dir, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Packages) != 2 || m.Packages["."] == nil || m.Packages["lib"] == nil {
+		t.Fatalf("packages = %v, want . and lib only", m.Packages)
+	}
+	lib := *m.Packages["lib"]
+	want := PackageMetrics{Dir: "lib", Name: "lib", Exported: 3, ExportedFuncs: 1, Funcs: 2, Types: 1, Tests: 1}
+	if lib != want {
+		t.Errorf("lib = %+v, want %+v", lib, want)
+	}
+	if m.Totals.Funcs != 3 || m.Totals.Tests != 1 {
+		t.Errorf("totals = %+v, want 3 funcs and 1 test", m.Totals)
+	}
+}
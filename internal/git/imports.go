@@ -0,0 +1,173 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportEdge is an import of one package of the module by another, both
+// given as directories relative to the module root ("." for the root).
+type ImportEdge struct {
+	From string
+	To   string
+}
+
+// ImportGraph holds the internal package imports of a Go module at one commit.
+// Test files are ignored so the graph reflects the shipped architecture.
+type ImportGraph struct {
+	Module string // module path from go.mod, empty if the tree has none
+	Edges  map[ImportEdge]bool
+}
+
+// Sorted returns the edges ordered by importing then imported package.
+func (g *ImportGraph) Sorted() []ImportEdge {
+	return sortEdges(g.Edges)
+}
+
+// Diff returns the edges present in g but not in prev, and those present in
+// prev but not in g. A nil prev is treated as an empty graph.
+func (g *ImportGraph) Diff(prev *ImportGraph) (added, removed []ImportEdge) {
+	old := map[ImportEdge]bool{}
+	if prev != nil {
+		old = prev.Edges
+	}
+	a, r := map[ImportEdge]bool{}, map[ImportEdge]bool{}
+	for e := range g.Edges {
+		if !old[e] {
+			a[e] = true
+		}
+	}
+	for e := range old {
+		if !g.Edges[e] {
+			r[e] = true
+		}
+	}
+	return sortEdges(a), sortEdges(r)
+}
+
+var (
+	importsCache = newLRU[string, *ImportGraph](commitCacheSize) // keyed by commit hash
+	fileImports  = newLRU[string, []string](blobCacheSize)       // keyed by blob object name
+)
+
+// LoadImportGraph builds the internal import graph of the Go module rooted at
+// the top of the repository, as of the given commit. Results are cached.
+// Directories the go tool ignores, such as vendor and testdata, are skipped.
+func LoadImportGraph(dir, hash string) (*ImportGraph, error) {
+	if cached, ok := importsCache.get(hash); ok {
+		return cached, nil
+	}
+
+	entries, err := ListTree(dir, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &ImportGraph{Edges: map[ImportEdge]bool{}}
+	var goFiles []TreeEntry
+	files := map[string][]string{} // imports by blob
+	var missing []string
+	for _, e := range entries {
+		switch {
+		case e.Path == "go.mod":
+			missing = append(missing, e.Blob)
+		case strings.HasSuffix(e.Path, ".go") && !strings.HasSuffix(e.Path, "_test.go") &&
+			!ignoredGoDir(path.Dir(e.Path)):
+			goFiles = append(goFiles, e)
+			if imports, ok := fileImports.get(e.Blob); ok {
+				files[e.Blob] = imports
+			} else {
+				missing = append(missing, e.Blob)
+			}
+		}
+	}
+
+	blobs, err := readBlobs(dir, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Path == "go.mod" {
+			g.Module = modulePath(blobs[e.Blob])
+		}
+	}
+
+	for _, e := range goFiles {
+		imports, ok := files[e.Blob]
+		if !ok {
+			imports = parseImports(e.Path, blobs[e.Blob])
+			fileImports.put(e.Blob, imports)
+		}
+		if g.Module == "" {
+			continue
+		}
+		from := path.Dir(e.Path)
+		for _, imp := range imports {
+			var to string
+			switch {
+			case imp == g.Module:
+				to = "."
+			case strings.HasPrefix(imp, g.Module+"/"):
+				to = strings.TrimPrefix(imp, g.Module+"/")
+			default:
+				continue
+			}
+			if to != from {
+				g.Edges[ImportEdge{From: from, To: to}] = true
+			}
+		}
+	}
+	importsCache.put(hash, g)
+	return g, nil
+}
+
+// parseImports returns the import paths of a Go file.
+func parseImports(filename string, src []byte) []string {
+	if src == nil {
+		return nil
+	}
+	fset := token.NewFileSet()
+	f, _ := parser.ParseFile(fset, filename, src, parser.ImportsOnly)
+	if f == nil {
+		return nil
+	}
+	var out []string
+	for _, spec := range f.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err == nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// modulePath extracts the module path from go.mod contents.
+func modulePath(gomod []byte) string {
+	sc := bufio.NewScanner(bytes.NewReader(gomod))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if rest, ok := strings.CutPrefix(line, "module"); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+func sortEdges(set map[ImportEdge]bool) []ImportEdge {
+	out := make([]ImportEdge, 0, len(set))
+	for e := range set {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		return out[i].To < out[j].To
+	})
+	return out
+}
@@ -0,0 +1,56 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportGraphDiff(t *testing.T) {
+	edges := func(es ...ImportEdge) map[ImportEdge]bool {
+		m := map[ImportEdge]bool{}
+		for _, e := range es {
+			m[e] = true
+		}
+		return m
+	}
+	ab, ac, bc := ImportEdge{"a", "b"}, ImportEdge{"a", "c"}, ImportEdge{"b", "c"}
+	prev := &ImportGraph{Edges: edges(ab, bc)}
+	cur := &ImportGraph{Edges: edges(ab, ac)}
+
+	added, removed := cur.Diff(prev)
+	if !reflect.DeepEqual(added, []ImportEdge{ac}) || !reflect.DeepEqual(removed, []ImportEdge{bc}) {
+		t.Fatalf("Diff = +%v -%v, want +[a→c] -[b→c]", added, removed)
+	}
+	added, removed = cur.Diff(nil)
+	if !reflect.DeepEqual(added, []ImportEdge{ab, ac}) || len(removed) != 0 {
+		t.Fatalf("Diff against nil = +%v -%v, want every edge added", added, removed)
+	}
+}
+
+func TestLoadImportGraph(t *testing.T) {
+	r := newTestRepo(t)
+	c := r.commit("dev@example.com", "add", map[string]string{
+		"go.mod":                 "module example.com/m\n\ngo 1.22\n",
+		"main.go":                "package main\n\nimport (\n\t\"fmt\"\n\t\"example.com/m/internal/ui\"\n)\n",
+		"internal/ui/ui.go":      "package ui\n\nimport \"example.com/m/internal/git\"\n",
+		"internal/ui/ui_test.go": "package ui\n\nimport _ \"example.com/m\"\n",
+		"internal/git/git.go":    "package git\n\nimport \"example.com/m/internal/git/sub\"\n",
+		"internal/git/sub/s.go":  "package sub\n",
+		"vendor/v/v.go":          "package v\n\nimport \"example.com/m/internal/ui\"\n",
+	})
+	g, err := LoadImportGraph(r.dir, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Module != "example.com/m" {
+		t.Errorf("Module = %q", g.Module)
+	}
+	want := []ImportEdge{
+		{".", "internal/ui"},
+		{"internal/git", "internal/git/sub"},
+		{"internal/ui", "internal/git"},
+	}
+	if got := g.Sorted(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("edges = %v, want %v", got, want)
+	}
+}
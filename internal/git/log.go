@@ -3,6 +3,8 @@ package git
 import (
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -17,9 +19,33 @@ type Commit struct {
 	Timestamp time.Time
 	Subject   string
 	Body      string
+	Parents   []string
 	Index     int // position in the full history (0-based)
 }
 
+// IsMerge reports whether the commit has more than one parent.
+func (c *Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// Parent returns the hash of the commit's first parent, or "" for a root
+// commit.
+func (c *Commit) Parent() string {
+	if len(c.Parents) == 0 {
+		return ""
+	}
+	return c.Parents[0]
+}
+
+// botAuthor matches the names and emails automation commits under.
+var botAuthor = regexp.MustCompile(`(?i)\[bot\]|\bbot\b|dependabot|renovate|github-actions`)
+
+// IsBot reports whether the commit was made by automation, judging by the
+// author's name and email.
+func (c *Commit) IsBot() bool {
+	return botAuthor.MatchString(c.Author + " " + c.Email)
+}
+
 // RelativeTime returns a human-friendly relative time string.
 func (c *Commit) RelativeTime() string {
 	d := time.Since(c.Timestamp)
@@ -70,6 +96,29 @@ func IsGitRepo(dir string) bool {
 	return cmd.Run() == nil
 }
 
+// GitDir returns the absolute path of the repository's git directory, shared
+// by all of its worktrees.
+func GitDir(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--git-common-dir").Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse: %w", err)
+	}
+	p := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(dir, p)
+	}
+	return p, nil
+}
+
+// TopLevel returns the root of the working tree that contains dir.
+func TopLevel(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // DefaultBranch returns the current branch name or HEAD.
 func DefaultBranch(dir string) string {
 	out, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
@@ -94,6 +143,45 @@ func ListBranches(dir string) ([]string, error) {
 	return branches, nil
 }
 
+// ResolveCommit returns the full hash of the commit a revision (hash prefix,
+// tag, branch or any other git revision) names.
+func ResolveCommit(dir, rev string) (string, error) {
+	if strings.HasPrefix(rev, "-") {
+		return "", fmt.Errorf("invalid revision %q", rev)
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--verify", "--quiet", rev+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown revision %q", rev)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// LoadTags returns tag names keyed by the hash of the commit they point at.
+// Annotated tags are peeled to their commit.
+func LoadTags(dir string) (map[string][]string, error) {
+	out, err := exec.Command(
+		"git", "-C", dir, "for-each-ref",
+		"--format=%(objectname) %(*objectname) %(refname:short)", "refs/tags",
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+	tags := map[string][]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		// "<object> <peeled commit, empty for lightweight tags> <name>"
+		parts := strings.SplitN(line, " ", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		hash := parts[1]
+		if hash == "" {
+			hash = parts[0]
+		}
+		tags[hash] = append(tags[hash], parts[2])
+	}
+	return tags, nil
+}
+
 // LoadHistory parses the full git commit log for the given directory and branch.
 // maxCount = 0 means no limit.
 func LoadHistory(dir, branch string, maxCount int) ([]Commit, error) {
@@ -101,7 +189,7 @@ func LoadHistory(dir, branch string, maxCount int) ([]Commit, error) {
 		"-C", dir,
 		"log",
 		"--reverse",
-		"--format=%H|%h|%an|%ae|%at|%s",
+		"--format=%H|%h|%an|%ae|%at|%P|%s",
 	}
 	if maxCount > 0 {
 		args = append(args, fmt.Sprintf("--max-count=%d", maxCount))
@@ -120,8 +208,8 @@ func LoadHistory(dir, branch string, maxCount int) ([]Commit, error) {
 		if line == "" {
 			continue
 		}
-		parts := strings.SplitN(line, "|", 6)
-		if len(parts) < 6 {
+		parts := strings.SplitN(line, "|", 7)
+		if len(parts) < 7 {
 			continue
 		}
 		ts, _ := strconv.ParseInt(parts[4], 10, 64)
@@ -131,13 +219,39 @@ func LoadHistory(dir, branch string, maxCount int) ([]Commit, error) {
 			Author:    parts[2],
 			Email:     parts[3],
 			Timestamp: time.Unix(ts, 0),
-			Subject:   parts[5],
+			Parents:   strings.Fields(parts[5]),
+			Subject:   parts[6],
 			Index:     i,
 		})
 	}
 	return commits, nil
 }
 
+// FirstCommits returns the hash of each author's first commit on a branch, by
+// email. It reads the whole history, so it also holds when LoadHistory was
+// limited to the latest commits.
+func FirstCommits(dir, branch string) (map[string]string, error) {
+	args := []string{"-C", dir, "log", "--reverse", "--format=%H|%ae"}
+	if branch != "" {
+		args = append(args, branch)
+	}
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	first := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		hash, email, ok := strings.Cut(line, "|")
+		if !ok {
+			continue
+		}
+		if _, seen := first[email]; !seen {
+			first[email] = hash
+		}
+	}
+	return first, nil
+}
+
 // TotalCommits returns the total number of commits on a branch without loading them all.
 func TotalCommits(dir, branch string) int {
 	args := []string{"-C", dir, "rev-list", "--count"}
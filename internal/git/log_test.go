@@ -0,0 +1,27 @@
+package git
+
+import "testing"
+
+func TestFirstCommitsSeesPastMax(t *testing.T) {
+	r := newTestRepo(t)
+	a1 := r.commit("alice@example.com", "one", map[string]string{"a.txt": "1"})
+	r.commit("alice@example.com", "two", map[string]string{"a.txt": "2"})
+	b1 := r.commit("bob@example.com", "three", map[string]string{"b.txt": "1"})
+	r.commit("alice@example.com", "four", map[string]string{"a.txt": "3"})
+
+	commits, err := LoadHistory(r.dir, "main", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0].Hash != b1 {
+		t.Fatalf("LoadHistory with max 2 = %v, want the latest two commits", commits)
+	}
+
+	first, err := FirstCommits(r.dir, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first["alice@example.com"] != a1 || first["bob@example.com"] != b1 || len(first) != 2 {
+		t.Fatalf("FirstCommits = %v, want alice at %s and bob at %s", first, a1, b1)
+	}
+}
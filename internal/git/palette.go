@@ -0,0 +1,102 @@
+package git
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// authorSymbols is the ordered set of symbols assigned to each new author.
+var authorSymbols = []string{"●", "◆", "▲", "■", "★", "✦", "◉", "⬟", "◈", "⬡", "◇", "▼"}
+
+// authorPalette is a curated set of vibrant, distinct colors for authors.
+var authorPalette = []lipgloss.Color{
+	"#7aa2f7", // blue
+	"#9ece6a", // green
+	"#f7768e", // red/pink
+	"#e0af68", // yellow
+	"#bb9af7", // purple
+	"#2ac3de", // cyan
+	"#ff9e64", // orange
+	"#73daca", // teal
+	"#b4f9f8", // light cyan
+	"#c0caf5", // lavender
+	"#f7c67f", // light orange
+	"#db4b4b", // deep red
+}
+
+// palettes are the named author palettes. The colorblind-safe ones keep
+// their colors apart under the common color vision deficiencies.
+var palettes = map[string][]lipgloss.Color{
+	"default": authorPalette,
+	// Okabe & Ito, with grey in place of black
+	"okabe-ito": {"#e69f00", "#56b4e9", "#009e73", "#f0e442", "#0072b2", "#d55e00", "#cc79a7", "#999999"},
+	// Paul Tol's bright and muted qualitative schemes
+	"tol-bright": {"#4477aa", "#ee6677", "#228833", "#ccbb44", "#66ccee", "#aa3377", "#bbbbbb"},
+	"tol-muted": {"#cc6677", "#332288", "#ddcc77", "#117733", "#88ccee", "#882255", "#44aa99",
+		"#999933", "#aa4499"},
+}
+
+// PaletteNames lists the named author palettes.
+func PaletteNames() []string {
+	names := make([]string, 0, len(palettes))
+	for n := range palettes {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Palette returns a named author palette.
+func Palette(name string) ([]lipgloss.Color, bool) {
+	p, ok := palettes[name]
+	return p, ok
+}
+
+// generatedIdentity returns the n-th color and symbol pair handed out to
+// authors. The first len(palette) authors get a color and symbol each; after
+// that every color is paired with every symbol in turn, and once all pairs
+// are used, new colors are generated. No pair repeats.
+func generatedIdentity(n int, palette []lipgloss.Color) (lipgloss.Color, string) {
+	c, s := len(palette), len(authorSymbols)
+	if n < c*s {
+		return palette[n%c], authorSymbols[(n/c+n%c)%s]
+	}
+	k := n - c*s
+	return goldenColor(k), authorSymbols[k%s]
+}
+
+// goldenColor generates the k-th extra color, stepping the hue by the golden
+// angle so consecutive colors are far apart, and cycling the lightness.
+func goldenColor(k int) lipgloss.Color {
+	hue := math.Mod(20+float64(k)*137.508, 360)
+	light := []float64{0.65, 0.75, 0.55}[k%3]
+	return hslColor(hue, 0.65, light)
+}
+
+// hslColor converts a hue in degrees and saturation and lightness in [0, 1]
+// to a hex color.
+func hslColor(h, s, l float64) lipgloss.Color {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	to8 := func(v float64) int { return int(math.Round((v + m) * 255)) }
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", to8(r), to8(g), to8(b)))
+}
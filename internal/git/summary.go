@@ -0,0 +1,70 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ChangeSummary is the size of a commit: the files it touched and the lines
+// it changed. Merge commits have an empty summary.
+type ChangeSummary struct {
+	Files     []string
+	Additions int
+	Deletions int
+}
+
+// Lines returns the number of added and deleted lines.
+func (s ChangeSummary) Lines() int {
+	return s.Additions + s.Deletions
+}
+
+// LoadChangeSummaries returns the change summary of every commit on a branch,
+// keyed by hash, from a single `git log --numstat` walk. It takes the same
+// branch and maxCount as LoadHistory.
+func LoadChangeSummaries(dir, branch string, maxCount int) (map[string]ChangeSummary, error) {
+	args := []string{
+		"-C", dir, "-c", "core.quotePath=off",
+		"log", "--numstat", "--no-renames",
+		"--format=commit %H",
+	}
+	if maxCount > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", maxCount))
+	}
+	if branch != "" {
+		args = append(args, branch)
+	}
+
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log --numstat: %w", err)
+	}
+
+	summaries := map[string]ChangeSummary{}
+	var hash string
+	var cur ChangeSummary
+	for _, line := range strings.Split(string(out), "\n") {
+		if h, ok := strings.CutPrefix(line, "commit "); ok {
+			if hash != "" {
+				summaries[hash] = cur
+			}
+			hash, cur = h, ChangeSummary{}
+			continue
+		}
+		// "<added>\t<deleted>\t<path>", "-" for binary files
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		add, _ := strconv.Atoi(parts[0])
+		del, _ := strconv.Atoi(parts[1])
+		cur.Files = append(cur.Files, parts[2])
+		cur.Additions += add
+		cur.Deletions += del
+	}
+	if hash != "" {
+		summaries[hash] = cur
+	}
+	return summaries, nil
+}
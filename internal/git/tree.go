@@ -0,0 +1,137 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// TreeEntry is one file in a commit's tree.
+type TreeEntry struct {
+	Path string
+	Blob string // object name of the file contents
+}
+
+// ListTree returns every file in the tree of the given commit along with the
+// object name of its contents.
+func ListTree(dir, hash string) ([]TreeEntry, error) {
+	out, err := exec.Command("git", "-C", dir, "ls-tree", "-r", "-z", hash).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-tree: %w", err)
+	}
+	var entries []TreeEntry
+	for _, rec := range strings.Split(string(out), "\x00") {
+		// "<mode> <type> <object>\t<path>"
+		meta, path, ok := strings.Cut(rec, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) < 3 || fields[1] != "blob" {
+			continue
+		}
+		entries = append(entries, TreeEntry{Path: path, Blob: fields[2]})
+	}
+	return entries, nil
+}
+
+// ListFiles returns the paths of every file in the tree of the given commit.
+func ListFiles(dir, hash string) ([]string, error) {
+	out, err := exec.Command("git", "-C", dir, "ls-tree", "-r", "-z", "--name-only", hash).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-tree: %w", err)
+	}
+	var paths []string
+	for _, p := range strings.Split(string(out), "\x00") {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// ReadFiles returns the contents of the given paths as of commit hash.
+// Paths that do not exist at that commit are omitted from the result.
+func ReadFiles(dir, hash string, paths []string) (map[string][]byte, error) {
+	specs := make([]string, len(paths))
+	for i, p := range paths {
+		specs[i] = hash + ":" + p
+	}
+	blobs, err := readBlobs(dir, specs)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]byte, len(blobs))
+	for i, p := range paths {
+		if b, ok := blobs[specs[i]]; ok {
+			out[p] = b
+		}
+	}
+	return out, nil
+}
+
+// readBlobs resolves object specs ("<rev>:<path>" or a bare object name)
+// through a single `git cat-file --batch` process. Missing objects are omitted.
+func readBlobs(dir string, specs []string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(specs))
+	if len(specs) == 0 {
+		return out, nil
+	}
+
+	var input bytes.Buffer
+	for _, s := range specs {
+		if strings.ContainsAny(s, "\n") {
+			// cat-file reads one spec per line; such paths cannot be asked for.
+			continue
+		}
+		input.WriteString(s + "\n")
+	}
+
+	cmd := exec.Command("git", "-C", dir, "cat-file", "--batch")
+	cmd.Stdin = &input
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+
+	r := bufio.NewReader(stdout)
+	for _, s := range specs {
+		if strings.ContainsAny(s, "\n") {
+			continue
+		}
+		header, err := r.ReadString('\n')
+		if err != nil {
+			break
+		}
+		// "<sha> <type> <size>" or "<spec> missing"
+		fields := strings.Fields(header)
+		if len(fields) < 3 || strings.HasSuffix(strings.TrimSpace(header), " missing") {
+			continue
+		}
+		size, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			break
+		}
+		buf := make([]byte, size+1) // content + trailing newline
+		if _, err := io.ReadFull(r, buf); err != nil {
+			break
+		}
+		if fields[1] == "blob" {
+			out[s] = buf[:size]
+		}
+	}
+	// Drain anything left so git never blocks on a full pipe.
+	_, _ = io.Copy(io.Discard, r)
+
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	return out, nil
+}
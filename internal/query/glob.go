@@ -0,0 +1,74 @@
+package query
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// MatchGlob reports whether file matches one of the path patterns, returning
+// the pattern that matched. It is the matcher behind path: terms, the
+// director's cut globs and the watched paths of pause-on-event, so a pattern
+// means the same everywhere.
+func MatchGlob(patterns []string, file string) (string, bool) {
+	for _, p := range patterns {
+		if compiledGlob(p).MatchString(file) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// globCache holds the patterns MatchGlob has compiled. They come from the
+// config and the command line, so there are few of them.
+var globCache sync.Map // pattern → *regexp.Regexp
+
+func compiledGlob(g string) *regexp.Regexp {
+	if re, ok := globCache.Load(g); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := globRegexp(g)
+	globCache.Store(g, re)
+	return re
+}
+
+// globRegexp compiles a path pattern. "*" and "?" stay within a directory
+// and "**" spans any number of them. Patterns without a slash match the base
+// name, and patterns without wildcards also match everything below them.
+func globRegexp(g string) *regexp.Regexp {
+	if !strings.ContainsAny(g, "*?") {
+		g = strings.TrimSuffix(g, "/")
+		if !strings.Contains(g, "/") {
+			return regexp.MustCompile(`(^|/)` + regexp.QuoteMeta(g) + `(/|$)`)
+		}
+		return regexp.MustCompile(`^` + regexp.QuoteMeta(g) + `(/|$)`)
+	}
+	var sb strings.Builder
+	if !strings.Contains(g, "/") {
+		sb.WriteString(`(^|/)`)
+	} else {
+		sb.WriteString(`^`)
+	}
+	for i := 0; i < len(g); i++ {
+		switch {
+		case strings.HasPrefix(g[i:], "**/"):
+			sb.WriteString(`(.*/)?`)
+			i += 2
+		case strings.HasPrefix(g[i:], "**"):
+			sb.WriteString(`.*`)
+			i++
+		case g[i] == '*':
+			sb.WriteString(`[^/]*`)
+		case g[i] == '?':
+			sb.WriteString(`[^/]`)
+		default:
+			sb.WriteString(regexp.QuoteMeta(g[i : i+1]))
+		}
+	}
+	sb.WriteString(`$`)
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return regexp.MustCompile(`^` + regexp.QuoteMeta(g) + `$`)
+	}
+	return re
+}
@@ -0,0 +1,363 @@
+// Package query parses the filter language shared by search and filter:
+// space-separated terms that must all match, such as
+//
+//	author:alice path:internal/** after:2024-01 size:>200 -merge type:feat
+//
+// A leading "-" negates a term, "OR" separates alternatives, commas separate
+// alternative values of one field, and "@name" stands for a named filter.
+// Words without a field match the subject, author or hash.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+// Fields are the field names a term can start with, for completion.
+var Fields = []string{"author", "path", "after", "before", "size", "files", "type", "msg", "hash"}
+
+// Keywords are the terms that stand alone.
+var Keywords = []string{"merge", "bot"}
+
+// Query is a parsed query. The zero value is not valid; use Parse.
+type Query struct {
+	text      string
+	groups    [][]term // alternatives, each a list of terms that must all match
+	summaries bool
+}
+
+// term is one condition. Terms on commit sizes need the change summary.
+type term struct {
+	neg       bool
+	match     func(c *git.Commit, s *git.ChangeSummary) bool
+	summaries bool
+}
+
+// Parse parses a query. Named filters referenced as @name are looked up in
+// named and may refer to each other, but not in a cycle.
+func Parse(s string, named map[string]string) (*Query, error) {
+	p := parser{named: named, seen: map[string]bool{}}
+	return p.parse(s)
+}
+
+// String returns the query as written.
+func (q *Query) String() string {
+	return q.text
+}
+
+// NeedsSummaries reports whether the query looks at the files or size of a
+// commit, so it can only run once the change summaries are loaded.
+func (q *Query) NeedsSummaries() bool {
+	return q.summaries
+}
+
+// Match reports whether a commit, with its change summary, matches.
+func (q *Query) Match(c git.Commit, s git.ChangeSummary) bool {
+	for _, g := range q.groups {
+		if matchAll(g, &c, &s) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAll(terms []term, c *git.Commit, s *git.ChangeSummary) bool {
+	for _, t := range terms {
+		if t.match(c, s) == t.neg {
+			return false
+		}
+	}
+	return true
+}
+
+type parser struct {
+	named map[string]string
+	seen  map[string]bool // named filters being expanded, to catch cycles
+}
+
+func (p *parser) parse(s string) (*Query, error) {
+	words, err := split(s)
+	if err != nil {
+		return nil, err
+	}
+	q := &Query{text: strings.TrimSpace(s)}
+	var group []term
+	var errs []error
+	for _, w := range words {
+		if w == "OR" || w == "|" {
+			if len(group) > 0 {
+				q.groups = append(q.groups, group)
+			}
+			group = nil
+			continue
+		}
+		t, err := p.term(w)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		q.summaries = q.summaries || t.summaries
+		group = append(group, t)
+	}
+	if len(group) > 0 || len(q.groups) == 0 {
+		q.groups = append(q.groups, group)
+	}
+	return q, errors.Join(errs...)
+}
+
+// split breaks a query into words at spaces outside double quotes, dropping
+// the quotes.
+func split(s string) ([]string, error) {
+	var words []string
+	var cur strings.Builder
+	quoted, inWord := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case unicode.IsSpace(r) && !quoted:
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+func (p *parser) term(w string) (term, error) {
+	neg := false
+	if len(w) > 1 && w[0] == '-' {
+		neg, w = true, w[1:]
+	}
+
+	if name, ok := strings.CutPrefix(w, "@"); ok {
+		t, err := p.namedTerm(name)
+		t.neg = neg
+		return t, err
+	}
+
+	field, value, ok := strings.Cut(w, ":")
+	if !ok {
+		switch strings.ToLower(w) {
+		case "merge":
+			return term{neg: neg, match: func(c *git.Commit, _ *git.ChangeSummary) bool { return c.IsMerge() }}, nil
+		case "bot":
+			return term{neg: neg, match: func(c *git.Commit, _ *git.ChangeSummary) bool { return c.IsBot() }}, nil
+		}
+		return term{neg: neg, match: wordMatcher(w)}, nil
+	}
+	if value == "" {
+		return term{}, fmt.Errorf("%s: missing value", w)
+	}
+
+	t := term{neg: neg}
+	var err error
+	switch strings.ToLower(field) {
+	case "author":
+		t.match = anyOf(value, func(v string) matcher {
+			v = strings.ToLower(v)
+			return func(c *git.Commit, _ *git.ChangeSummary) bool {
+				return strings.Contains(strings.ToLower(c.Author), v) || strings.Contains(strings.ToLower(c.Email), v)
+			}
+		})
+	case "msg":
+		// Only subjects are loaded with the history.
+		t.match = anyOf(value, func(v string) matcher {
+			v = strings.ToLower(v)
+			return func(c *git.Commit, _ *git.ChangeSummary) bool {
+				return strings.Contains(strings.ToLower(c.Subject), v)
+			}
+		})
+	case "hash":
+		t.match = anyOf(value, func(v string) matcher {
+			v = strings.ToLower(v)
+			return func(c *git.Commit, _ *git.ChangeSummary) bool { return strings.HasPrefix(c.Hash, v) }
+		})
+	case "type":
+		t.match = anyOf(value, func(v string) matcher {
+			return func(c *git.Commit, _ *git.ChangeSummary) bool { return strings.EqualFold(commitType(c.Subject), v) }
+		})
+	case "path":
+		t.summaries = true
+		var globs []*regexp.Regexp
+		for _, v := range strings.Split(value, ",") {
+			globs = append(globs, globRegexp(v))
+		}
+		t.match = func(_ *git.Commit, s *git.ChangeSummary) bool {
+			for _, f := range s.Files {
+				for _, re := range globs {
+					if re.MatchString(f) {
+						return true
+					}
+				}
+			}
+			return false
+		}
+	case "after", "before":
+		var at time.Time
+		if at, err = ParseDate(value, time.Now()); err == nil {
+			before := strings.EqualFold(field, "before")
+			t.match = func(c *git.Commit, _ *git.ChangeSummary) bool {
+				return c.Timestamp.Before(at) == before
+			}
+		}
+	case "size", "files":
+		var cmp func(int) bool
+		if cmp, err = parseComparison(value); err == nil {
+			t.summaries = true
+			if strings.EqualFold(field, "size") {
+				t.match = func(_ *git.Commit, s *git.ChangeSummary) bool { return cmp(s.Lines()) }
+			} else {
+				t.match = func(_ *git.Commit, s *git.ChangeSummary) bool { return cmp(len(s.Files)) }
+			}
+		}
+	default:
+		return term{}, fmt.Errorf("%s: unknown field %q (available: %s)", w, field, strings.Join(Fields, ", "))
+	}
+	if err != nil {
+		return term{}, fmt.Errorf("%s: %w", w, err)
+	}
+	return t, nil
+}
+
+// namedTerm expands a named filter into a single term.
+func (p *parser) namedTerm(name string) (term, error) {
+	text, ok := p.named[name]
+	if !ok {
+		return term{}, fmt.Errorf("@%s: no such filter", name)
+	}
+	if p.seen[name] {
+		return term{}, fmt.Errorf("@%s: refers to itself", name)
+	}
+	p.seen[name] = true
+	defer delete(p.seen, name)
+	q, err := p.parse(text)
+	if err != nil {
+		return term{}, fmt.Errorf("@%s: %w", name, err)
+	}
+	return term{
+		match:     func(c *git.Commit, s *git.ChangeSummary) bool { return q.Match(*c, *s) },
+		summaries: q.summaries,
+	}, nil
+}
+
+type matcher = func(c *git.Commit, s *git.ChangeSummary) bool
+
+// anyOf matches when any of the comma-separated values does.
+func anyOf(value string, one func(v string) matcher) matcher {
+	var ms []matcher
+	for _, v := range strings.Split(value, ",") {
+		if v != "" {
+			ms = append(ms, one(v))
+		}
+	}
+	return func(c *git.Commit, s *git.ChangeSummary) bool {
+		for _, m := range ms {
+			if m(c, s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// wordMatcher matches a bare word against the subject, the author and the
+// short hash, as search always has.
+func wordMatcher(w string) matcher {
+	w = strings.ToLower(w)
+	return func(c *git.Commit, _ *git.ChangeSummary) bool {
+		return strings.Contains(strings.ToLower(c.Subject), w) ||
+			strings.Contains(strings.ToLower(c.Author), w) ||
+			strings.Contains(c.ShortHash, w)
+	}
+}
+
+// conventional matches a Conventional Commits subject, e.g. "feat(ui)!: …".
+var conventional = regexp.MustCompile(`^(\w+)(\([^)]*\))?!?:`)
+
+// commitType returns the Conventional Commits type of a subject, or "".
+func commitType(subject string) string {
+	if m := conventional.FindStringSubmatch(subject); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// dateLayouts are the absolute dates ParseDate accepts.
+var dateLayouts = []string{"2006-01-02 15:04", "2006-01-02", "2006-01", "2006"}
+
+// relativeDate matches dates relative to now, e.g. "30d", "2w", "6mo", "1y".
+var relativeDate = regexp.MustCompile(`^(\d+)(d|w|mo|y)$`)
+
+// ParseDate parses an absolute date, meaning its start, or a date relative
+// to now, as after:, before: and the palette's date command take them.
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if m := relativeDate.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "d":
+			return now.AddDate(0, 0, -n), nil
+		case "w":
+			return now.AddDate(0, 0, -7*n), nil
+		case "mo":
+			return now.AddDate(0, -n, 0), nil
+		default:
+			return now.AddDate(-n, 0, 0), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("want YYYY-MM-DD, YYYY-MM, YYYY or an age like 30d, 2w, 6mo, 1y, got %q", s)
+}
+
+// parseComparison parses a number with an optional comparison, e.g. ">200",
+// "<=3" or "10".
+func parseComparison(s string) (func(int) bool, error) {
+	op := strings.TrimRight(s, "0123456789")
+	n, err := strconv.Atoi(s[len(op):])
+	if err != nil {
+		return nil, fmt.Errorf("want a number with an optional >, >=, < or <=, got %q", s)
+	}
+	switch op {
+	case ">":
+		return func(v int) bool { return v > n }, nil
+	case ">=":
+		return func(v int) bool { return v >= n }, nil
+	case "<":
+		return func(v int) bool { return v < n }, nil
+	case "<=":
+		return func(v int) bool { return v <= n }, nil
+	case "", "=":
+		return func(v int) bool { return v == n }, nil
+	}
+	return nil, fmt.Errorf("unknown comparison %q", op)
+}
+
+// ValidName reports whether a filter name can be written as @name.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
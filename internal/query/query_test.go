@@ -0,0 +1,305 @@
+package query
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+func TestMatch(t *testing.T) {
+	c := git.Commit{
+		Hash: "abcdef1234567890", ShortHash: "abcdef1",
+		Author: "Alice Smith", Email: "alice@example.com",
+		Subject: "feat(ui): add layouts", Body: "Closes #12.",
+		Timestamp: time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local),
+		Parents:   []string{"p1"},
+	}
+	s := git.ChangeSummary{Files: []string{"internal/ui/layout.go", "README.md"}, Additions: 150, Deletions: 60}
+	named := map[string]string{"big": "size:>200", "ui": "path:internal/ui/**", "both": "@big @ui"}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"author:alice path:internal/** after:2024-01 size:>200 -merge type:feat", true},
+		{"", true},
+
+		// words
+		{"layouts", true},
+		{"smith", true},
+		{"abcdef", true},
+		{"nothing", false},
+
+		// fields and alternatives
+		{"author:bob", false},
+		{"author:bob,alice", true},
+		{"author:example.com", true},
+		{`author:"Alice Smith"`, true},
+		{`author:"Bob Smith"`, false},
+		{"msg:add", true},
+		{"msg:closes", false}, // bodies are not searched
+		{"hash:abcd", true},
+		{"hash:bcde", false},
+		{"type:feat", true},
+		{"type:FEAT", true},
+		{"type:fix", false},
+		{"type:fix,feat", true},
+
+		// paths
+		{"path:internal", true},
+		{"path:internal/", true},
+		{"path:internal/u", false},
+		{"path:internal/**", true},
+		{"path:internal/*", false},
+		{"path:internal/*/*.go", true},
+		{"path:**/layout.go", true},
+		{"path:layout.go", true},
+		{"path:*.md", true},
+		{"path:*.txt", false},
+		{"path:ui", true},
+		{"path:docs,README.md", true},
+
+		// dates
+		{"after:2024", true},
+		{"after:2024-03", true},
+		{"after:2024-03-02", false},
+		{"before:2024-03-02", true},
+		{"before:2024-03", false},
+		{"after:1000y", true},
+		{"before:1d", true},
+		{"after:1d", false},
+
+		// sizes
+		{"size:210", true},
+		{"size:=210", true},
+		{"size:>210", false},
+		{"size:>=210", true},
+		{"size:<211", true},
+		{"size:<=209", false},
+		{"files:2", true},
+		{"files:>2", false},
+
+		// keywords, negation and OR
+		{"merge", false},
+		{"-merge", true},
+		{"bot", false},
+		{"-bot", true},
+		{"-author:alice", false},
+		{"author:bob OR type:feat", true},
+		{"author:bob | type:feat", true},
+		{"author:bob OR type:fix", false},
+		{"OR type:feat", true},
+
+		// named filters
+		{"@big", true},
+		{"@ui", true},
+		{"@both", true},
+		{"-@big", false},
+		{"@big author:bob", false},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query, named)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.query, err)
+			continue
+		}
+		if got := q.Match(c, s); got != tt.want {
+			t.Errorf("Parse(%q).Match = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMatchMergeAndBot(t *testing.T) {
+	c := git.Commit{Author: "dependabot[bot]", Email: "bot@example.com", Parents: []string{"a", "b"}}
+	for query, want := range map[string]bool{"merge": true, "bot": true, "-merge": false, "merge -bot": false} {
+		q, err := Parse(query, nil)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", query, err)
+		}
+		if got := q.Match(c, git.ChangeSummary{}); got != want {
+			t.Errorf("Parse(%q).Match = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestNeedsSummaries(t *testing.T) {
+	named := map[string]string{"big": "size:>200"}
+	for query, want := range map[string]bool{
+		"author:alice": false, "after:2024": false, "path:a": true,
+		"size:>1": true, "files:2": true, "@big": true, "x OR -@big": true,
+	} {
+		q, err := Parse(query, named)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", query, err)
+		}
+		if got := q.NeedsSummaries(); got != want {
+			t.Errorf("Parse(%q).NeedsSummaries = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	named := map[string]string{"self": "@self", "a": "@b", "b": "author:x @a"}
+	tests := []struct {
+		query string
+		want  string // part of the error
+	}{
+		{"foo:bar", `unknown field "foo"`},
+		{"path:", "missing value"},
+		{"size:~3", `unknown comparison "~"`},
+		{"size:big", "want a number"},
+		{"after:yesterday", "want YYYY-MM-DD"},
+		{"before:2024-13", "want YYYY-MM-DD"},
+		{`author:"Alice`, "unterminated quote"},
+		{"@nope", "@nope: no such filter"},
+		{"@self", "@self: refers to itself"},
+		{"@a", "@a: refers to itself"},
+		{"foo:1 bar:2", `unknown field "bar"`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.query, named)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error = %v, want one containing %q", tt.query, err, tt.want)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2024, 5, 31, 10, 0, 0, 0, time.Local)
+	tests := map[string]time.Time{
+		"2023":             time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local),
+		"2023-07":          time.Date(2023, 7, 1, 0, 0, 0, 0, time.Local),
+		"2023-07-04":       time.Date(2023, 7, 4, 0, 0, 0, 0, time.Local),
+		"2023-07-04 15:30": time.Date(2023, 7, 4, 15, 30, 0, 0, time.Local),
+		"3d":               now.AddDate(0, 0, -3),
+		"2w":               now.AddDate(0, 0, -14),
+		"6mo":              now.AddDate(0, -6, 0),
+		"1y":               now.AddDate(-1, 0, 0),
+	}
+	for s, want := range tests {
+		got, err := ParseDate(s, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for name, want := range map[string]bool{"big": true, "api-v2_x": true, "": false, "a b": false, "@x": false} {
+		if got := ValidName(name); got != want {
+			t.Errorf("ValidName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestSavedRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gitcinema", "filters.json")
+
+	s, err := OpenSaved(path)
+	if err != nil {
+		t.Fatalf("OpenSaved on a missing file: %v", err)
+	}
+	if len(s.All()) != 0 {
+		t.Fatalf("new store has %v", s.All())
+	}
+	if err := s.Set("big", "size:>200"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("ui", `path:internal/ui/** author:"Jane Doe"`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("big", "size:>500"); err != nil {
+		t.Fatal(err)
+	}
+
+	s2, err := OpenSaved(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"big": "size:>500", "ui": `path:internal/ui/** author:"Jane Doe"`}
+	if got := s2.All(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("reloaded %v, want %v", got, want)
+	}
+
+	if ok, err := s2.Delete("big"); !ok || err != nil {
+		t.Fatalf("Delete(big) = %v, %v", ok, err)
+	}
+	if ok, err := s2.Delete("big"); ok || err != nil {
+		t.Fatalf("second Delete(big) = %v, %v", ok, err)
+	}
+	s3, err := OpenSaved(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s3.All(); !reflect.DeepEqual(got, map[string]string{"ui": want["ui"]}) {
+		t.Fatalf("after delete reloaded %v", got)
+	}
+
+	// All returns a copy.
+	s3.All()["x"] = "y"
+	if _, ok := s3.All()["x"]; ok {
+		t.Fatal("All exposes the store's map")
+	}
+}
+
+func TestSavedKeepsFiltersWhenSavingFails(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenSaved(filepath.Join(dir, "filters.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("big", "size:>200"); err != nil {
+		t.Fatal(err)
+	}
+	// A path below a regular file cannot be written.
+	s.path = filepath.Join(dir, "filters.json", "filters.json")
+
+	want := map[string]string{"big": "size:>200"}
+	if err := s.Set("big", "size:>500"); err == nil {
+		t.Fatal("Set saved below a file")
+	}
+	if err := s.Set("ui", "path:ui"); err == nil {
+		t.Fatal("Set saved below a file")
+	}
+	if got := s.All(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after failed Sets the store has %v, want %v", got, want)
+	}
+	if ok, err := s.Delete("big"); ok || err == nil {
+		t.Fatalf("Delete = %v, %v, want a failure", ok, err)
+	}
+	if got := s.All(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after a failed Delete the store has %v, want %v", got, want)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"go.sum", "go.sum", true},
+		{"go.sum", "tools/go.sum", true},
+		{"*.lock", "web/yarn.lock", true},
+		{"*.lock", "web/yarn.locked", false},
+		{"api/", "api/v1/handler.go", true},
+		{"api", "api/v1/handler.go", true},
+		{"internal/ui", "internal/ui/model.go", true},
+		{"internal/ui", "internal/uikit/model.go", false},
+		{"internal/**", "internal/ui/model.go", true},
+		{"internal/*", "internal/ui/model.go", false},
+		{"internal/*/*.go", "internal/ui/model.go", true},
+		{"**/testdata/**", "internal/git/testdata/a.txt", true},
+		{"docs/*.md", "README.md", false},
+		{"[", "[", true},
+	}
+	for _, tt := range tests {
+		p, ok := MatchGlob([]string{"unrelated.txt", tt.pattern}, tt.file)
+		if ok != tt.want || ok && p != tt.pattern {
+			t.Errorf("MatchGlob(%q, %q) = %q, %v, want %v", tt.pattern, tt.file, p, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+)
+
+// Saved holds the named filters saved from the palette, one file per
+// repository, next to its bookmarks.
+type Saved struct {
+	path  string
+	items map[string]string
+}
+
+// savedFile is the on-disk format.
+type savedFile struct {
+	Version int               `json:"version"`
+	Filters map[string]string `json:"filters"`
+}
+
+const savedVersion = 1
+
+// SavedPath returns where the saved filters of the repository with the given
+// git directory are kept.
+func SavedPath(gitDir string) string {
+	return filepath.Join(gitDir, "gitcinema", "filters.json")
+}
+
+// OpenSaved loads the filters saved at path. A missing file is an empty set.
+func OpenSaved(path string) (*Saved, error) {
+	s := &Saved{path: path, items: map[string]string{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading saved filters: %w", err)
+	}
+	var f savedFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("reading saved filters from %s: %w", path, err)
+	}
+	if f.Version > savedVersion {
+		return nil, fmt.Errorf("reading saved filters from %s: unsupported version %d", path, f.Version)
+	}
+	maps.Copy(s.items, f.Filters)
+	return s, nil
+}
+
+// All returns a copy of the saved filters by name.
+func (s *Saved) All() map[string]string {
+	return maps.Clone(s.items)
+}
+
+// Set saves a filter under a name, replacing any filter of that name. When
+// saving fails the filters are left as they were.
+func (s *Saved) Set(name, query string) error {
+	old, had := s.items[name]
+	s.items[name] = query
+	if err := s.save(); err != nil {
+		if had {
+			s.items[name] = old
+		} else {
+			delete(s.items, name)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes a saved filter and reports whether there was one. When
+// saving fails the filter is kept.
+func (s *Saved) Delete(name string) (bool, error) {
+	old, ok := s.items[name]
+	if !ok {
+		return false, nil
+	}
+	delete(s.items, name)
+	if err := s.save(); err != nil {
+		s.items[name] = old
+		return false, err
+	}
+	return true, nil
+}
+
+// save writes the filters through a temporary file, so an interrupted write
+// never leaves a truncated file behind.
+func (s *Saved) save() error {
+	data, err := json.MarshalIndent(savedFile{Version: savedVersion, Filters: s.items}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("saving filters: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("saving filters: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("saving filters: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("saving filters: %w", err)
+	}
+	return nil
+}
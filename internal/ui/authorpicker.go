@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+// authorMark is an author's state in the author filter. When any author is
+// included, only included authors play; excluded authors never do.
+type authorMark int
+
+const (
+	markNone authorMark = iota
+	markInclude
+	markExclude
+)
+
+// authorCount is a row of the author picker.
+type authorCount struct {
+	author  *git.Author
+	commits int
+}
+
+// openAuthorPicker opens the picker on a copy of the applied marks, listing
+// authors by commit count.
+func (m *Model) openAuthorPicker() {
+	counts := map[string]int{}
+	for _, c := range m.commits {
+		counts[c.Email]++
+	}
+	m.pickList = nil
+	for _, a := range m.registry.All() {
+		m.pickList = append(m.pickList, authorCount{a, counts[a.Email]})
+	}
+	sort.SliceStable(m.pickList, func(i, j int) bool { return m.pickList[i].commits > m.pickList[j].commits })
+
+	m.pickMarks = maps.Clone(m.authorMarks)
+	if m.pickMarks == nil {
+		m.pickMarks = map[string]authorMark{}
+	}
+	m.pickSel = 0
+	m.state = StatePickingAuthors
+}
+
+func (m Model) handleAuthorPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := &m.keys
+	var email string
+	if m.pickSel < len(m.pickList) {
+		email = m.pickList[m.pickSel].author.Email
+	}
+	toggle := func(mark authorMark) {
+		if email == "" {
+			return
+		}
+		if m.pickMarks[email] == mark {
+			delete(m.pickMarks, email)
+		} else {
+			m.pickMarks[email] = mark
+		}
+	}
+
+	switch {
+	case key.Matches(msg, k.PickerCancel):
+		m.state = StateReady
+	case key.Matches(msg, k.Help):
+		m.openHelp()
+	case key.Matches(msg, k.PickerApply):
+		m.state = StateReady
+		return m, m.applyAuthorFilter(m.pickMarks)
+	case key.Matches(msg, k.PickerUp):
+		m.pickSel = max(m.pickSel-1, 0)
+	case key.Matches(msg, k.PickerDown):
+		m.pickSel = max(min(m.pickSel+1, len(m.pickList)-1), 0)
+	case key.Matches(msg, k.PickerInclude):
+		toggle(markInclude)
+	case key.Matches(msg, k.PickerExclude):
+		toggle(markExclude)
+	case key.Matches(msg, k.PickerOnly):
+		if email != "" {
+			m.pickMarks = map[string]authorMark{email: markInclude}
+		}
+	case key.Matches(msg, k.PickerReset):
+		m.pickMarks = map[string]authorMark{}
+	}
+	return m, nil
+}
+
+// applyAuthorFilter narrows the history to the authors the marks let
+// through, on top of any filter query. No marks clears the author filter.
+func (m *Model) applyAuthorFilter(marks map[string]authorMark) tea.Cmd {
+	m.recordJump()
+	m.cancelFuncs()
+	m.funcFilter = nil
+	m.authorMarks = nil
+	for email, mark := range marks {
+		if mark != markNone {
+			if m.authorMarks == nil {
+				m.authorMarks = map[string]authorMark{}
+			}
+			m.authorMarks[email] = mark
+		}
+	}
+	return m.refilter()
+}
+
+// SetAuthorFilter filters the history to authors matching q once it has
+// loaded.
+func (m *Model) SetAuthorFilter(q string) {
+	m.startAuthor = q
+}
+
+// filterByAuthor includes every author whose name or email contains q, as
+// --author does; an empty q clears the author filter.
+func (m *Model) filterByAuthor(q string) tea.Cmd {
+	marks := map[string]authorMark{}
+	if q != "" {
+		lq := strings.ToLower(q)
+		for _, a := range m.registry.All() {
+			if strings.Contains(strings.ToLower(a.Name), lq) || strings.Contains(strings.ToLower(a.Email), lq) {
+				marks[a.Email] = markInclude
+			}
+		}
+		if len(marks) == 0 {
+			m.notice = fmt.Sprintf("no authors match %q", q)
+			return nil
+		}
+	}
+	return m.applyAuthorFilter(marks)
+}
+
+// authorActive reports whether the applied author filter lets an author's
+// commits through.
+func (m *Model) authorActive(email string) bool {
+	return markActive(m.authorMarks, email)
+}
+
+// markActive reports whether marks let an author's commits through.
+func markActive(marks map[string]authorMark, email string) bool {
+	switch marks[email] {
+	case markInclude:
+		return true
+	case markExclude:
+		return false
+	}
+	for _, mark := range marks {
+		if mark == markInclude {
+			return false
+		}
+	}
+	return true
+}
+
+// authorFilterLabel summarises the applied author filter for the legend,
+// e.g. "2 of 9 authors", or "" when there is none.
+func authorFilterLabel(m *Model) string {
+	if m.authorMarks == nil {
+		return ""
+	}
+	all := m.registry.All()
+	n := 0
+	for _, a := range all {
+		if m.authorActive(a.Email) {
+			n++
+		}
+	}
+	return fmt.Sprintf("%d of %d authors", n, len(all))
+}
+
+// authorTag renders an author's legend tag, dimmed when the author filter
+// hides them and struck through when they are excluded.
+func authorTag(m *Model, a *git.Author) string {
+	switch {
+	case m.authorMarks[a.Email] == markExclude:
+		return lipgloss.NewStyle().Foreground(ColorDim).Strikethrough(true).Render(a.Symbol + " " + a.Name)
+	case !m.authorActive(a.Email):
+		return lipgloss.NewStyle().Foreground(ColorDim).Render(a.Symbol + " " + a.Name)
+	}
+	return a.Tag()
+}
+
+// renderAuthorPicker renders the author picker overlay.
+func renderAuthorPicker(m *Model) string {
+	var sb strings.Builder
+	sb.WriteString(TitleStyle.Render("🎭 Authors") +
+		HelpStyle.Render(fmt.Sprintf("%d of %d shown", pickedCount(m), len(m.pickList))) + "\n")
+	sb.WriteString(strings.Repeat("─", max(m.width-6, 0)) + "\n")
+
+	if len(m.pickList) == 0 {
+		sb.WriteString(HelpStyle.Render("  No authors loaded."))
+		return sb.String()
+	}
+
+	nameW := 0
+	for _, ac := range m.pickList {
+		nameW = max(nameW, lipgloss.Width(ac.author.Name))
+	}
+	nameW = min(nameW, max(m.width/3, 8))
+
+	visH := m.overlayRows()
+	start := 0
+	if m.pickSel >= visH {
+		start = m.pickSel - visH + 1
+	}
+	for i := start; i < len(m.pickList) && i < start+visH; i++ {
+		a, n := m.pickList[i].author, m.pickList[i].commits
+		box := HelpStyle.Render("[ ]")
+		switch m.pickMarks[a.Email] {
+		case markInclude:
+			box = StatAddStyle.Render("[+]")
+		case markExclude:
+			box = StatDelStyle.Render("[-]")
+		}
+		name := lipgloss.NewStyle().Foreground(a.Color).Bold(true).Width(nameW).Render(truncate(a.Name, nameW))
+		line := fmt.Sprintf("  %s %s %s  %s  %s", box, a.Badge(), name,
+			HelpStyle.Render(fmt.Sprintf("%5d commits", n)),
+			DateStyle.Render(truncate(a.Email, max(m.width-nameW-32, 3))))
+		switch {
+		case i == m.pickSel:
+			line = SelectedStyle.Width(m.width - 6).Render(line)
+		case !markActive(m.pickMarks, a.Email):
+			line = lipgloss.NewStyle().Faint(true).Render(line)
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// pickedCount is how many authors the picker's marks let through.
+func pickedCount(m *Model) int {
+	n := 0
+	for _, ac := range m.pickList {
+		if markActive(m.pickMarks, ac.author.Email) {
+			n++
+		}
+	}
+	return n
+}
+
+// renderAuthorPickerBar renders the key help under the author picker.
+func renderAuthorPickerBar(m *Model) string {
+	k := &m.keys
+	return statusBar(m, "", []string{
+		shortHelp("include", k.PickerInclude),
+		shortHelp("exclude", k.PickerExclude),
+		shortHelp("only", k.PickerOnly),
+		shortHelp("all", k.PickerReset),
+		shortHelp("apply", k.PickerApply),
+		shortHelp("cancel", k.PickerCancel),
+		shortHelp("select", k.PickerDown, k.PickerUp),
+		shortHelp("keys", k.Help),
+	})
+}
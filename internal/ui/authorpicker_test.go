@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+func TestAuthorPickerBeforeHistoryLoads(t *testing.T) {
+	m := New(".", "", 10)
+	tm, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if m = tm.(Model); m.state != StateLoading {
+		t.Fatalf("state = %v after f while loading, want StateLoading", m.state)
+	}
+	if c := m.queryCandidates("author:"); c != nil {
+		t.Fatalf("author completions while loading = %v, want none", c)
+	}
+}
+
+func TestDismissingAuthorPickerKeepsFunctionTrace(t *testing.T) {
+	m := New(".", "", 0)
+	m.state = StateReady
+	m.commits = []git.Commit{{Hash: "a", Email: "a@x"}, {Hash: "b", Email: "b@x"}}
+	m.registry = git.BuildRegistry(m.commits, git.Identities{})
+	m.traceFunc(git.FuncRef{Name: "F"})
+	gen := m.funcGen
+
+	var tm tea.Model = m
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = tm.(Model)
+	if m.state != StateReady || m.funcGen != gen || !m.funcsLoading {
+		t.Fatalf("after opening and dismissing the picker: state %v, funcGen %d (want %d), loading %v",
+			m.state, m.funcGen, gen, m.funcsLoading)
+	}
+
+	tm, _ = m.Update(funcHistoryMsg{gen: gen, fn: git.FuncRef{Name: "F"}, hashes: map[string]bool{"b": true}})
+	if m = tm.(Model); len(m.filteredCommits) != 1 || m.filteredCommits[0].Hash != "b" {
+		t.Fatalf("filtered = %v, want [b]", m.filteredCommits)
+	}
+}
@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/meetsoni15/gitcinema/internal/bookmarks"
+)
+
+// SetBookmarks sets the store bookmarks are read from and saved to.
+func (m *Model) SetBookmarks(s *bookmarks.Store) {
+	m.bookmarks = s
+}
+
+// startNote opens the note input for a bookmark on the given commit,
+// prefilled with its current note.
+func (m *Model) startNote(hash string) {
+	if m.bookmarks == nil || hash == "" {
+		return
+	}
+	m.noteHash = hash
+	m.noteInput = ""
+	if b, ok := m.bookmarks.Get(hash); ok {
+		m.noteInput = b.Note
+	}
+	m.state = StateNoting
+}
+
+func (m Model) handleNoteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.state = StateReady
+		m.noteInput = ""
+	case key.Matches(msg, m.keys.Accept):
+		subject := ""
+		for _, c := range m.commits {
+			if c.Hash == m.noteHash {
+				subject = c.Subject
+				break
+			}
+		}
+		if err := m.bookmarks.Set(bookmarks.Bookmark{
+			Hash: m.noteHash, Note: strings.TrimSpace(m.noteInput), Subject: subject,
+		}); err != nil {
+			m.notice = err.Error()
+		}
+		m.state = StateReady
+		m.noteInput = ""
+	case key.Matches(msg, m.keys.DeleteBack):
+		if r := []rune(m.noteInput); len(r) > 0 {
+			m.noteInput = string(r[:len(r)-1])
+		}
+	default:
+		if len(msg.Runes) > 0 {
+			m.noteInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// bookmarkList returns the bookmarks in history order; those on commits
+// outside the loaded history come last.
+func (m *Model) bookmarkList() []bookmarks.Bookmark {
+	if m.bookmarks == nil {
+		return nil
+	}
+	pos := make(map[string]int, len(m.commits))
+	for i, c := range m.commits {
+		pos[c.Hash] = i
+	}
+	at := func(b bookmarks.Bookmark) int {
+		if i, ok := pos[b.Hash]; ok {
+			return i
+		}
+		return len(m.commits)
+	}
+	all := m.bookmarks.All()
+	sort.SliceStable(all, func(i, j int) bool { return at(all[i]) < at(all[j]) })
+	return all
+}
+
+func (m Model) handleBookmarkKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	list := m.bookmarkList()
+	m.notice = ""
+	switch {
+	case key.Matches(msg, m.keys.ListClose):
+		m.state = StateReady
+	case key.Matches(msg, m.keys.Help):
+		m.openHelp()
+	case key.Matches(msg, m.keys.ListUp):
+		if m.bmSel > 0 {
+			m.bmSel--
+		}
+	case key.Matches(msg, m.keys.ListDown):
+		if m.bmSel < len(list)-1 {
+			m.bmSel++
+		}
+	case key.Matches(msg, m.keys.ListJump):
+		if m.bmSel < len(list) {
+			if i, ok := m.indexOf(list[m.bmSel].Hash); ok {
+				m.state = StateReady
+				return m, m.bigJump(i)
+			}
+		}
+	case key.Matches(msg, m.keys.ListEdit):
+		if m.bmSel < len(list) {
+			m.startNote(list[m.bmSel].Hash)
+		}
+	case key.Matches(msg, m.keys.ListDelete):
+		if m.bmSel < len(list) {
+			if err := m.bookmarks.Delete(list[m.bmSel].Hash); err != nil {
+				m.notice = err.Error()
+				break
+			}
+			m.bmSel = max(0, min(m.bmSel, len(list)-2))
+		}
+	}
+	return m, nil
+}
+
+// jumpBookmark moves to the next (dir > 0) or previous bookmarked frame.
+func (m *Model) jumpBookmark(dir int) tea.Cmd {
+	if m.bookmarks == nil {
+		return nil
+	}
+	ac := m.activeCommits()
+	for i := m.cursor + dir; i >= 0 && i < len(ac); i += dir {
+		if m.bookmarks.Has(ac[i].Hash) {
+			return m.bigJump(i)
+		}
+	}
+	return nil
+}
+
+// bookmarkNote returns the bookmark flag and note shown for a commit, or "".
+func bookmarkNote(m *Model, hash string) string {
+	if m.bookmarks == nil {
+		return ""
+	}
+	b, ok := m.bookmarks.Get(hash)
+	if !ok {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(ColorDeleted).Bold(true).Render(strings.TrimSpace("⚑ " + b.Note))
+}
+
+// renderBookmarkList renders the bookmark overlay.
+func renderBookmarkList(m *Model) string {
+	var sb strings.Builder
+	sb.WriteString(TitleStyle.Render("⚑ Bookmarks") + "\n")
+	sb.WriteString(strings.Repeat("─", max(m.width-6, 0)) + "\n")
+
+	list := m.bookmarkList()
+	if len(list) == 0 {
+		sb.WriteString(HelpStyle.Render("  No bookmarks yet — press b on a frame to add one."))
+		return sb.String()
+	}
+
+	visH := m.overlayRows()
+	start := 0
+	if m.bmSel >= visH {
+		start = m.bmSel - visH + 1
+	}
+	for i := start; i < len(list) && i < start+visH; i++ {
+		b := list[i]
+		pos, hash, subject := "   ?", b.Hash, b.Subject
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		dim := false
+		if j, ok := m.indexOf(b.Hash); ok {
+			c := m.activeCommits()[j]
+			pos, hash, subject = fmt.Sprintf("%4d", j+1), c.ShortHash, c.Subject
+		} else {
+			dim = true // outside the loaded or filtered history
+		}
+		line := fmt.Sprintf("  %s  %s  %s  %s",
+			HelpStyle.Render(pos), HashStyle.Render(hash),
+			lipgloss.NewStyle().Foreground(ColorText).Render(truncate(subject, m.width/3)),
+			lipgloss.NewStyle().Foreground(ColorModified).Render(truncate(b.Note, m.width/3)),
+		)
+		switch {
+		case i == m.bmSel:
+			line = SelectedStyle.Width(m.width - 6).Render(line)
+		case dim:
+			line = lipgloss.NewStyle().Faint(true).Render(line)
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// renderBookmarkBar renders the key help under the bookmark overlay.
+func renderBookmarkBar(m *Model) string {
+	n := 0
+	if m.bookmarks != nil {
+		n = m.bookmarks.Len()
+	}
+	k := &m.keys
+	return statusBar(m, m.notice, []string{
+		HelpStyle.Render(fmt.Sprintf("%d bookmarks", n)),
+		shortHelp("select", k.ListDown, k.ListUp),
+		shortHelp("jump", k.ListJump),
+		shortHelp("edit note", k.ListEdit),
+		shortHelp("delete", k.ListDelete),
+		shortHelp("close", k.ListClose),
+		shortHelp("keys", k.Help),
+	})
+}
+
+// renderNoteBar renders the bookmark note input.
+func renderNoteBar(m *Model) string {
+	hash := m.noteHash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	prompt := lipgloss.NewStyle().Foreground(ColorDeleted).Bold(true).Render("⚑ note for " + hash + ":")
+	input := lipgloss.NewStyle().Foreground(ColorText).Render(m.noteInput)
+	cursor := lipgloss.NewStyle().Foreground(ColorDeleted).Render("█")
+	return StatusBarStyle.Width(m.width).Render("  " + prompt + " " + input + cursor + "  " +
+		helpLine(shortHelp("save", m.keys.Accept), shortHelp("cancel", m.keys.Cancel)))
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderCommitList renders the left pane listing the commits around the
+// cursor. Only the visible window is rendered, so it stays cheap on very long
+// histories.
+func renderCommitList(m *Model) string {
+	ac := m.activeCommits()
+	h := m.leftVP.Height
+	start := commitListStart(m)
+
+	var sb strings.Builder
+	sb.WriteString(TitleStyle.Render("🎞 Commits") +
+		HelpStyle.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(ac))) + "\n")
+	sb.WriteString(strings.Repeat("─", max(m.leftWidth-2, 0)) + "\n")
+
+	lines := make([]string, 0, h)
+	for i := start; i < start+h && i < len(ac); i++ {
+		lines = append(lines, renderCommitRow(m, i))
+	}
+	for len(lines) < h {
+		lines = append(lines, "")
+	}
+	sb.WriteString(strings.Join(lines, "\n") + "\n")
+
+	if len(ac) > 0 {
+		sb.WriteString(SubtitleStyle.Render(fmt.Sprintf(
+			"  %s → %s", ac[0].FormattedDate(), ac[len(ac)-1].FormattedDate(),
+		)))
+	}
+	return sb.String()
+}
+
+// renderCommitRow renders one commit line: badge, short hash and subject.
+func renderCommitRow(m *Model, i int) string {
+	c := m.activeCommits()[i]
+
+	badge := " "
+	if a := m.registry.Get(c.Email); a != nil {
+		badge = a.Badge()
+	}
+	tag := ""
+	if len(m.tags[c.Hash]) > 0 {
+		tag = lipgloss.NewStyle().Foreground(ColorModified).Bold(true).Render("◆ ")
+	}
+	if m.bookmarks != nil && m.bookmarks.Has(c.Hash) {
+		tag += lipgloss.NewStyle().Foreground(ColorDeleted).Bold(true).Render("⚑ ")
+	}
+	subjectW := m.leftWidth - 6 - len(c.ShortHash) - lipgloss.Width(tag) - 2
+	line := fmt.Sprintf(" %s %s  %s%s", badge, HashStyle.Render(c.ShortHash), tag,
+		lipgloss.NewStyle().Foreground(ColorText).Render(truncate(c.Subject, subjectW)))
+
+	switch {
+	case i == m.cursor:
+		line = SelectedStyle.Width(m.leftWidth - 2).Render(line)
+	case m.isCut(i):
+		line = lipgloss.NewStyle().Faint(true).Render(line)
+	}
+	return line
+}
+
+// commitListStart returns the index of the first listed commit, keeping the
+// cursor centered while playback scrolls the list.
+func commitListStart(m *Model) int {
+	total := len(m.activeCommits())
+	h := m.leftVP.Height
+	start := m.cursor - h/2
+	return max(0, min(start, total-h))
+}
+
+// scrollCommitList moves the cursor for a scroll key pressed on the list.
+func (m *Model) scrollCommitList(mv scrollMove) tea.Cmd {
+	page := max(m.leftVP.Height, 1)
+	switch mv {
+	case scrollLineDown:
+		return m.jumpTo(m.cursor + 1)
+	case scrollLineUp:
+		return m.jumpTo(m.cursor - 1)
+	case scrollPageDown:
+		return m.jumpTo(m.cursor + page)
+	case scrollPageUp:
+		return m.jumpTo(m.cursor - page)
+	case scrollHalfDown:
+		return m.jumpTo(m.cursor + page/2)
+	case scrollHalfUp:
+		return m.jumpTo(m.cursor - page/2)
+	case scrollTop:
+		return m.jumpTo(0)
+	case scrollBottom:
+		return m.jumpTo(len(m.activeCommits()) - 1)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/meetsoni15/gitcinema/internal/config"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+// Configure applies a validated config on top of the current settings and
+// reports the settings the UI does not recognise, such as unknown actions or
+// themes. Everything valid is applied even when an error is returned.
+func (m *Model) Configure(c *config.Config) error {
+	var errs []error
+
+	d := c.Defaults
+	if d.Speed != nil {
+		m.SetSpeed(*d.Speed)
+	}
+	if iv := d.IntervalDuration(); iv > 0 {
+		m.interval = iv
+	}
+	if d.Realtime != nil {
+		m.realtime = *d.Realtime
+	}
+	if d.Loop != nil {
+		m.loop = *d.Loop
+	}
+	if d.Compression != "" {
+		if i, ok := compressionIndex(d.Compression); ok {
+			m.compressionIdx = i
+		} else {
+			errs = append(errs, fmt.Errorf("defaults.compression: unknown value %q (available: %s)",
+				d.Compression, strings.Join(compressionNames(), ", ")))
+		}
+	}
+
+	cut := m.cutRules
+	if c.Cut.Globs != nil {
+		cut.Globs = c.Cut.Globs
+	}
+	setInt(&cut.MaxLines, c.Cut.MaxLines)
+	setBool(&cut.Merges, c.Cut.Merges)
+	setBool(&cut.Bots, c.Cut.Bots)
+	setBool(&cut.FastForward, c.Cut.FastForward)
+	if c.Cut.Messages != nil {
+		cut.Messages = config.Regexps(c.Cut.Messages)
+	}
+	cutOn := m.cutOn
+	setBool(&cutOn, d.Cut)
+	m.SetCut(cut, cutOn)
+
+	pauses := m.pauseRules
+	if c.Pause.Paths != nil {
+		pauses.Paths = c.Pause.Paths
+	}
+	setBool(&pauses.NewAuthors, c.Pause.NewAuthors)
+	setBool(&pauses.Tags, c.Pause.Tags)
+	setInt(&pauses.MinLines, c.Pause.MinLines)
+	if c.Pause.Messages != nil {
+		pauses.Messages = config.Regexps(c.Pause.Messages)
+	}
+	pauseOn := m.pauseOn
+	setBool(&pauseOn, d.Pause)
+	m.SetPauses(pauses, pauseOn)
+
+	if len(c.Keys) > 0 {
+		if err := m.SetKeys(c.Keys); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if t, err := loadTheme(c.Theme); err != nil {
+		errs = append(errs, fmt.Errorf("theme: %w", err))
+	} else {
+		ApplyTheme(t)
+	}
+
+	if name := c.Authors.Palette; name != "" {
+		if p, ok := git.Palette(name); ok {
+			m.identities.Colors = p
+		} else {
+			errs = append(errs, fmt.Errorf("authors.palette: unknown palette %q (available: %s)",
+				name, strings.Join(git.PaletteNames(), ", ")))
+		}
+	}
+	if len(c.Authors.Colors) > 0 {
+		m.identities.Colors = nil
+	}
+	for _, col := range c.Authors.Colors {
+		m.identities.Colors = append(m.identities.Colors, lipgloss.Color(col))
+	}
+	if len(c.Authors.Pins) > 0 {
+		m.identities.Pins = map[string]git.Identity{}
+		for who, p := range c.Authors.Pins {
+			m.identities.Pins[who] = git.Identity{Name: p.Name, Color: lipgloss.Color(p.Color), Symbol: p.Symbol}
+		}
+	}
+
+	if c.Layout.Split != nil {
+		m.split = *c.Layout.Split
+	}
+	if c.Layout.Mode != "" {
+		if l, ok := layoutByName(c.Layout.Mode); ok {
+			m.layout = l
+		} else {
+			errs = append(errs, fmt.Errorf("layout.mode: unknown layout %q (available: %s)",
+				c.Layout.Mode, strings.Join(layoutNames, ", ")))
+		}
+	}
+	setBool(&m.zen, c.Layout.Zen)
+	if m.zen {
+		m.activePane = PaneDetail
+	}
+
+	if c.Filters != nil {
+		m.configFilters = c.Filters
+	}
+	return errors.Join(errs...)
+}
+
+// loadTheme resolves a theme name from the config: a built-in theme, "auto"
+// when unset, or a user theme file.
+func loadTheme(name string) (Theme, error) {
+	if name == "" {
+		name = "auto"
+	}
+	t, err := ThemeByName(name)
+	if err == nil {
+		return t, nil
+	}
+	path := config.ThemePath(name)
+	tf, ferr := config.LoadTheme(path)
+	if errors.Is(ferr, os.ErrNotExist) {
+		return t, err
+	}
+	if ferr != nil {
+		return t, ferr
+	}
+	base := tf.Base
+	if base == "" {
+		base = "dark"
+	}
+	if t, err = ThemeByName(base); err != nil {
+		return t, fmt.Errorf("%s: base: %w", path, err)
+	}
+	return t.WithColors(tf.Colors)
+}
+
+// compressionIndex finds a compression preset by its label without the
+// "/s", e.g. "1d".
+func compressionIndex(name string) (int, bool) {
+	for i, d := range compressionPresets {
+		if compressionLabel(d) == strings.TrimSuffix(name, "/s")+"/s" {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func compressionNames() []string {
+	names := make([]string, len(compressionPresets))
+	for i, d := range compressionPresets {
+		names[i] = strings.TrimSuffix(compressionLabel(d), "/s")
+	}
+	return names
+}
+
+func setBool(dst *bool, v *bool) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func setInt(dst *int, v *int) {
+	if v != nil {
+		*dst = *v
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/git"
+	"github.com/meetsoni15/gitcinema/internal/query"
+)
+
+// CutRules decide which frames the director's cut drops from playback.
+type CutRules struct {
+	Globs       []string         // frames touching only files matching these
+	MaxLines    int              // frames changing fewer lines than this, 0 = off
+	Merges      bool             // merge commits
+	Bots        bool             // commits by bot authors
+	Messages    []*regexp.Regexp // subjects matching any of these
+	FastForward bool             // flash cut frames briefly instead of skipping them
+}
+
+// DefaultCutRules cuts lockfile bumps, merges and bot commits.
+func DefaultCutRules() CutRules {
+	return CutRules{
+		Globs: []string{
+			"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml",
+			"Cargo.lock", "poetry.lock", "Gemfile.lock", "composer.lock",
+		},
+		Merges: true,
+		Bots:   true,
+	}
+}
+
+// cutFrame is how long a cut frame shows in fast-forward mode.
+const cutFrame = 60 * time.Millisecond
+
+// SetCut sets the director's cut rules and whether the cut starts enabled.
+func (m *Model) SetCut(rules CutRules, on bool) {
+	m.cutRules = rules
+	m.cutOn = on
+	m.summariesLoading = m.summariesLoading || on // Init starts the load
+}
+
+// toggleCut switches the director's cut, loading commit sizes the first time.
+func (m *Model) toggleCut() tea.Cmd {
+	m.cutOn = !m.cutOn
+	if m.cutOn {
+		return m.ensureSummaries()
+	}
+	return nil
+}
+
+// applyCut decides which commits the rules cut, once their sizes are loaded.
+func (m *Model) applyCut() {
+	m.cut = map[string]string{}
+	m.cutGen++
+	for _, c := range m.commits {
+		if r := m.cutRules.reason(c, m.summaries[c.Hash]); r != "" {
+			m.cut[c.Hash] = r
+		}
+	}
+}
+
+// reason returns why the rules cut commit c, or "" when they keep it.
+func (r CutRules) reason(c git.Commit, s git.ChangeSummary) string {
+	switch {
+	case r.Merges && c.IsMerge():
+		return "merge"
+	case r.Bots && c.IsBot():
+		return "bot"
+	}
+	for _, re := range r.Messages {
+		if re.MatchString(c.Subject) {
+			return "message /" + re.String() + "/"
+		}
+	}
+	if g, ok := r.onlyGlobs(s.Files); ok {
+		return "only " + g
+	}
+	if r.MaxLines > 0 && len(s.Files) > 0 && s.Lines() < r.MaxLines {
+		return fmt.Sprintf("< %d lines", r.MaxLines)
+	}
+	return ""
+}
+
+// onlyGlobs reports whether every file matches one of the globs, returning
+// the first glob that matched.
+func (r CutRules) onlyGlobs(files []string) (string, bool) {
+	if len(files) == 0 || len(r.Globs) == 0 {
+		return "", false
+	}
+	first := ""
+	for _, f := range files {
+		g, ok := query.MatchGlob(r.Globs, f)
+		if !ok {
+			return "", false
+		}
+		if first == "" {
+			first = g
+		}
+	}
+	return first, true
+}
+
+// activeCut returns the cut frames keyed by hash, or nil when the director's
+// cut is off or not loaded yet.
+func (m *Model) activeCut() map[string]string {
+	if !m.cutOn {
+		return nil
+	}
+	return m.cut
+}
+
+// cutCount returns how many frames of the active history the cut drops.
+func cutCount(m *Model) int {
+	n := 0
+	for i := range m.activeCommits() {
+		if m.isCut(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// isCut reports whether the director's cut drops frame i.
+func (m *Model) isCut(i int) bool {
+	ac := m.activeCommits()
+	return i >= 0 && i < len(ac) && m.activeCut()[ac[i].Hash] != ""
+}
@@ -6,26 +6,43 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/meetsoni15/gitcinema/internal/git"
+	"github.com/meetsoni15/gitcinema/internal/query"
 )
 
-// renderDetail renders the right pane showing commit details.
-func renderDetail(m *Model) string {
-	var sb strings.Builder
+// renderRightPane renders the right pane: a fixed title over the scrollable
+// commit detail or import graph.
+func renderRightPane(m *Model) string {
+	title := "📝 Commit"
+	if m.showImports {
+		title = "🕸 Import Graph"
+	}
+	return paneTitle(title, "", m.rightVP, m.rightWidth-4) + "\n" + m.rightVP.View()
+}
+
+// rightPaneContent renders every line of the right pane; the viewport shows
+// a window of it.
+func rightPaneContent(m *Model) string {
+	if m.showImports {
+		return importGraphContent(m)
+	}
+	return detailContent(m)
+}
 
-	sb.WriteString(TitleStyle.Render("📝 Commit") + "\n")
-	sb.WriteString(strings.Repeat("─", m.rightWidth-4) + "\n")
+// detailContent renders the commit details.
+func detailContent(m *Model) string {
+	var sb strings.Builder
 
-	if len(m.commits) == 0 || m.cursor >= len(m.commits) {
+	if len(m.activeCommits()) == 0 || m.cursor >= len(m.activeCommits()) {
 		sb.WriteString(HelpStyle.Render("  No commits loaded."))
 		return sb.String()
 	}
 
-	c := m.commits[m.cursor]
+	c := m.currentCommit()
 
 	// ── Hash ─────────────────────────────────────────────────────────────────
 	sb.WriteString(
 		HashStyle.Render("  "+c.ShortHash) + "  " +
-			SubtitleStyle.Render(c.Hash[:16]+"…") + "\n\n",
+			SubtitleStyle.Render(truncate(c.Hash, m.rightWidth-lipgloss.Width(c.ShortHash)-6)) + "\n\n",
 	)
 
 	// ── Subject ───────────────────────────────────────────────────────────────
@@ -52,7 +69,7 @@ func renderDetail(m *Model) string {
 
 	// ── Divider ───────────────────────────────────────────────────────────────
 	sb.WriteString(
-		lipgloss.NewStyle().Foreground(ColorDim).Render("  "+strings.Repeat("─", m.rightWidth-6)) + "\n\n",
+		lipgloss.NewStyle().Foreground(ColorDim).Render("  "+strings.Repeat("─", max(m.rightWidth-6, 0))) + "\n\n",
 	)
 
 	// ── Diff stats ────────────────────────────────────────────────────────────
@@ -65,25 +82,26 @@ func renderDetail(m *Model) string {
 				HelpStyle.Render(fmt.Sprintf("%d file(s) changed", d.Files)) + "\n\n",
 		)
 
+		// ── Go structure ──────────────────────────────────────────────────────
+		if m.showGoMetrics {
+			sb.WriteString(renderGoMetrics(m) + "\n")
+		}
+
 		// ── File change list ──────────────────────────────────────────────────
 		sb.WriteString(
 			lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).Render("  Files Changed") + "\n",
 		)
 
-		limit := 20
-		for i, fc := range d.Changes {
-			if i >= limit {
-				sb.WriteString(HelpStyle.Render(fmt.Sprintf("  … and %d more", len(d.Changes)-limit)) + "\n")
-				break
-			}
+		for _, fc := range d.Changes {
 			prefix := styledChangePrefix(fc.Status)
-			name := truncate(fc.Path, m.rightWidth-18)
 			stat := ""
 			if fc.Additions > 0 || fc.Deletions > 0 {
 				stat = " " +
 					lipgloss.NewStyle().Foreground(ColorAdded).Render(fmt.Sprintf("+%d", fc.Additions)) + " " +
 					lipgloss.NewStyle().Foreground(ColorDeleted).Render(fmt.Sprintf("-%d", fc.Deletions))
 			}
+			// padding, indent, prefix and the space after it
+			name := truncate(fc.Path, m.rightWidth-6-lipgloss.Width(stat))
 			sb.WriteString(fmt.Sprintf("  %s %s%s\n", prefix, name, stat))
 		}
 	} else if m.loadingDiff {
@@ -99,23 +117,86 @@ func renderDetail(m *Model) string {
 	return sb.String()
 }
 
+// renderGoMetrics renders the Go package stats of the current frame and how
+// the commit changed them.
+func renderGoMetrics(m *Model) string {
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).Render("  Go Structure") + "\n")
+
+	g := m.goMetrics
+	if g == nil {
+		sb.WriteString(HelpStyle.Render("  parsing…") + "\n")
+		return sb.String()
+	}
+
+	prev := &git.GoMetrics{}
+	if m.goMetricsPrev != nil {
+		prev = m.goMetricsPrev
+	}
+	counter := func(n, old int, label string) string {
+		s := lipgloss.NewStyle().Foreground(ColorText).Render(fmt.Sprintf("%d", n)) +
+			HelpStyle.Render(" "+label)
+		switch {
+		case n > old:
+			s += StatAddStyle.Render(fmt.Sprintf(" +%d", n-old))
+		case n < old:
+			s += StatDelStyle.Render(fmt.Sprintf(" -%d", old-n))
+		}
+		return s
+	}
+	sb.WriteString("  " + strings.Join([]string{
+		counter(len(g.Packages), len(prev.Packages), "pkgs"),
+		counter(g.Totals.Exported, prev.Totals.Exported, "exported"),
+		counter(g.Totals.Funcs, prev.Totals.Funcs, "funcs"),
+		counter(g.Totals.Types, prev.Totals.Types, "types"),
+		counter(g.Totals.Tests, prev.Totals.Tests, "tests"),
+	}, HelpStyle.Render(" · ")) + "\n")
+
+	changes := g.Changes(m.goMetricsPrev)
+	limit := 6
+	for i, c := range changes {
+		if i >= limit {
+			sb.WriteString(HelpStyle.Render(fmt.Sprintf("  … and %d more", len(changes)-limit)) + "\n")
+			break
+		}
+		style := StatAddStyle
+		if c.Delta < 0 {
+			style = StatDelStyle
+		}
+		sb.WriteString("  " + style.Render(truncate(c.String(), m.rightWidth-8)) + "\n")
+	}
+	return sb.String()
+}
+
 // renderSearchResults renders commit list filtered by search.
 func renderSearchResults(m *Model) string {
 	var sb strings.Builder
 	sb.WriteString(TitleStyle.Render("🔍 Search Results") + "\n")
-	sb.WriteString(strings.Repeat("─", m.width-4) + "\n")
+	sb.WriteString(strings.Repeat("─", max(m.width-6, 0)) + "\n")
 
-	if len(m.searchResults) == 0 {
+	switch {
+	case m.searchErr != nil:
+		for _, line := range strings.Split(m.searchErr.Error(), "\n") {
+			sb.WriteString(StatDelStyle.Render("  "+line) + "\n")
+		}
+		sb.WriteString(HelpStyle.Render("  Fields: " + strings.Join(query.Fields, " ") + " · keywords: " +
+			strings.Join(query.Keywords, " ") + " · -term negates · OR for alternatives"))
+		return sb.String()
+	case m.searchPending:
+		sb.WriteString(HelpStyle.Render("  Loading change sizes…"))
+		return sb.String()
+	case len(m.searchResults) == 0:
 		sb.WriteString(HelpStyle.Render("  No commits match \"" + m.searchQuery + "\""))
 		return sb.String()
 	}
 
-	visH := m.height - 10
+	ac := m.activeCommits()
+	visH := m.overlayRows()
 	for i, idx := range m.searchResults {
 		if i >= visH {
 			break
 		}
-		c := m.commits[idx]
+		c := ac[idx]
 		var authorTag string
 		if reg := m.registry; reg != nil {
 			if a := reg.Get(c.Email); a != nil {
@@ -148,6 +229,3 @@ func miniStatBar(adds, dels int) string {
 	return lipgloss.NewStyle().Foreground(ColorAdded).Render(strings.Repeat("█", addW)) +
 		lipgloss.NewStyle().Foreground(ColorDeleted).Render(strings.Repeat("█", delW))
 }
-
-// Ensure git import is used
-var _ = git.StatusAdded
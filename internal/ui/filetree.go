@@ -8,65 +8,122 @@ import (
 	"github.com/meetsoni15/gitcinema/internal/git"
 )
 
-// renderFileTree renders the left pane showing files changed in the current commit.
+// renderFileTree renders the left pane: a fixed title, the scrollable tree of
+// changed files (or the whole repository) and a summary footer.
 func renderFileTree(m *Model) string {
-	var sb strings.Builder
+	if m.leftView == ViewCommitList {
+		return renderCommitList(m)
+	}
+
+	title := "📂 Changed Files"
+	if m.leftView == ViewRepoTree {
+		title = "🌳 Repository"
+	}
+	return paneTitle(title, HelpStyle.Render("by "+m.treeSort.String()), m.leftVP, m.leftWidth-2) + "\n" +
+		m.leftVP.View() + "\n" +
+		fileTreeFooter(m)
+}
 
-	sb.WriteString(TitleStyle.Render("📂 Changed Files") + "\n")
-	sb.WriteString(strings.Repeat("─", m.leftWidth-2) + "\n")
+// fileTreeContent renders every line of the left pane tree; the viewport
+// shows a window of it.
+func fileTreeContent(m *Model) string {
+	if m.leftView == ViewCommitList {
+		return "" // rendered directly, see renderCommitList
+	}
+	if m.leftView == ViewRepoTree {
+		if m.repoFilesErr != nil {
+			return StatDelStyle.Render("  " + m.repoFilesErr.Error())
+		}
+		if m.fileTree == nil {
+			return HelpStyle.Render("  loading…")
+		}
+		if len(m.fileTree.children) == 0 {
+			return HelpStyle.Render("  empty tree")
+		}
+		return renderTreeRows(m)
+	}
 
 	if m.currentDiff == nil {
 		if m.loadingDiff {
-			sb.WriteString(HelpStyle.Render("  loading…"))
-		} else {
-			sb.WriteString(HelpStyle.Render("  select a commit"))
+			return HelpStyle.Render("  loading…")
 		}
-		return sb.String()
+		return HelpStyle.Render("  select a commit")
 	}
-
-	diff := m.currentDiff
-	if len(diff.Changes) == 0 {
-		sb.WriteString(HelpStyle.Render("  no file changes"))
-		return sb.String()
+	if len(m.currentDiff.Changes) == 0 {
+		return HelpStyle.Render("  no file changes")
 	}
+	return renderTreeRows(m)
+}
 
-	// Visible height
-	visH := m.height - 14
-	if visH < 1 {
-		visH = 1
+// fileTreeFooter renders the summary line under the left pane tree.
+func fileTreeFooter(m *Model) string {
+	if m.leftView == ViewRepoTree {
+		if m.fileTree == nil {
+			return ""
+		}
+		return SubtitleStyle.Render(fmt.Sprintf(
+			"  %d file(s) · %d changed", len(m.repoFiles), m.fileTree.changed,
+		))
 	}
+	if m.currentDiff == nil {
+		return ""
+	}
+	return SubtitleStyle.Render(fmt.Sprintf("  %d file(s)  ", m.currentDiff.Files)) +
+		renderFileStats(m.currentDiff.Additions, m.currentDiff.Deletions)
+}
 
-	// Scroll to keep fileScroll in view
-	start := m.fileScroll
-	end := start + visH
-	if end > len(diff.Changes) {
-		end = len(diff.Changes)
+// renderTreeRows renders one line per visible row of the left pane tree.
+func renderTreeRows(m *Model) string {
+	rows := m.treeRows()
+	if m.treeSel >= len(rows) {
+		m.treeSel = len(rows) - 1
 	}
+	lines := make([]string, len(rows))
+	for i, r := range rows {
+		lines[i] = renderTreeRow(m, r, i == m.treeSel)
+	}
+	return strings.Join(lines, "\n")
+}
 
-	for _, fc := range diff.Changes[start:end] {
-		prefix := styledChangePrefix(fc.Status)
-		name := truncate(fc.Path, m.leftWidth-12)
+// renderTreeRow renders one directory or file line of a tree.
+func renderTreeRow(m *Model, r treeRow, selected bool) string {
+	n := r.node
+	indent := strings.Repeat("  ", r.depth)
 
-		if fc.Status == git.StatusRenamed && fc.OldPath != "" {
-			name = truncate(fc.OldPath, m.leftWidth/2-4) + " → " + truncate(fc.Path, m.leftWidth/2-4)
-		}
+	stat := ""
+	if n.adds > 0 || n.dels > 0 {
+		stat = " " + lipgloss.NewStyle().Foreground(ColorAdded).Render(fmt.Sprintf("+%d", n.adds)) +
+			lipgloss.NewStyle().Foreground(ColorDeleted).Render(fmt.Sprintf("-%d", n.dels))
+	}
+	width := m.leftWidth - 6 - 2*r.depth - lipgloss.Width(stat)
 
-		statStr := ""
-		if fc.Additions > 0 || fc.Deletions > 0 {
-			statStr = " " + lipgloss.NewStyle().Foreground(ColorAdded).Render(fmt.Sprintf("+%d", fc.Additions)) +
-				lipgloss.NewStyle().Foreground(ColorDeleted).Render(fmt.Sprintf("-%d", fc.Deletions))
+	var line string
+	switch {
+	case n.dir:
+		icon := "▸"
+		if isExpanded(n, m.treeExpanded) {
+			icon = "▾"
 		}
-
-		line := fmt.Sprintf(" %s %s%s", prefix, name, statStr)
-		sb.WriteString(line + "\n")
+		style := lipgloss.NewStyle().Foreground(ColorSubtle)
+		if n.changed > 0 {
+			style = lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+		}
+		line = " " + indent + style.Render(icon+" "+truncate(n.name+"/", width))
+	case n.change != nil:
+		prefix := n.change.Status.Prefix()
+		name := n.name
+		if n.change.Status == git.StatusRenamed && n.change.OldPath != "" {
+			name += " ← " + n.change.OldPath
+		}
+		line = " " + indent + styledChangePrefix(n.change.Status) + " " +
+			ChangeStyle(prefix).UnsetBold().Render(truncate(name, width))
+	default:
+		line = " " + indent + "  " + lipgloss.NewStyle().Foreground(ColorSubtle).Render(truncate(n.name, width))
 	}
+	line += stat
 
-	// Summary footer
-	sb.WriteString("\n")
-	sb.WriteString(SubtitleStyle.Render(fmt.Sprintf(
-		"  %d file(s)  ", diff.Files,
-	)))
-	sb.WriteString(renderFileStats(diff.Additions, diff.Deletions))
-
-	return sb.String()
+	if selected && m.activePane == PaneFiles {
+		line = SelectedStyle.Width(m.leftWidth - 2).Render(line)
+	}
+	return line
 }
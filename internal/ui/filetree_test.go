@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+func TestRepoTreeShowsLoadError(t *testing.T) {
+	m := New(".", "", 0)
+	m.state = StateReady
+	m.commits = []git.Commit{{Hash: "a"}}
+	m.registry = git.BuildRegistry(m.commits, git.Identities{})
+	m.leftView = ViewRepoTree
+
+	var tm tea.Model = m
+	tm, _ = tm.Update(repoFilesMsg{hash: "a", err: errors.New("git ls-tree: exit status 128")})
+	m = tm.(Model)
+	if got := fileTreeContent(&m); !strings.Contains(got, "exit status 128") {
+		t.Fatalf("repo tree shows %q, want the load error", got)
+	}
+
+	tm, _ = tm.Update(repoFilesMsg{hash: "a", paths: []string{"a.go"}})
+	m = tm.(Model)
+	if got := fileTreeContent(&m); strings.Contains(got, "exit status") {
+		t.Fatalf("repo tree still shows the error after loading: %q", got)
+	}
+}
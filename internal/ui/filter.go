@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/git"
+	"github.com/meetsoni15/gitcinema/internal/query"
+)
+
+// SetSavedFilters gives the model the store the palette's save and unsave
+// commands write named filters to.
+func (m *Model) SetSavedFilters(s *query.Saved) {
+	m.savedFilters = s
+}
+
+// SetQueryFilter filters the history with a query once it has loaded, as the
+// palette's filter command does. Named filters must be set first.
+func (m *Model) SetQueryFilter(s string) error {
+	q, err := m.parseQuery(s)
+	if err != nil {
+		return fmt.Errorf("--filter: %w", err)
+	}
+	m.queryFilter = q
+	return nil
+}
+
+// namedFilters returns every named filter: the config's, then the saved ones,
+// which win on a name clash.
+func (m *Model) namedFilters() map[string]string {
+	named := maps.Clone(m.configFilters)
+	if named == nil {
+		named = map[string]string{}
+	}
+	if m.savedFilters != nil {
+		maps.Copy(named, m.savedFilters.All())
+	}
+	return named
+}
+
+// parseQuery parses a query with the named filters in scope.
+func (m *Model) parseQuery(s string) (*query.Query, error) {
+	return query.Parse(s, m.namedFilters())
+}
+
+// filterByQuery applies a filter query on top of the author filter; an empty
+// query clears both.
+func (m *Model) filterByQuery(s string) tea.Cmd {
+	if s == "" {
+		m.recordJump()
+		m.cancelFuncs()
+		m.funcFilter, m.authorMarks, m.queryFilter = nil, nil, nil
+		return m.refilter()
+	}
+	q, err := m.parseQuery(s)
+	if err != nil {
+		m.notice = "filter: " + strings.ReplaceAll(err.Error(), "\n", "; ")
+		return nil
+	}
+	m.recordJump()
+	m.cancelFuncs()
+	m.funcFilter = nil
+	m.queryFilter = q
+	return m.refilter()
+}
+
+// refilter rebuilds the filtered history from the author filter and the
+// filter query and moves to its first frame. A query on commit sizes waits
+// for the change summaries and runs again once they arrive.
+func (m *Model) refilter() tea.Cmd {
+	m.filteredCommits = nil
+	m.filterPending = false
+	q := m.queryFilter
+	if q != nil && q.NeedsSummaries() && m.summaries == nil {
+		m.filterPending = true
+		return m.ensureSummaries()
+	}
+	if m.authorMarks != nil || q != nil {
+		m.filteredCommits = []git.Commit{}
+		for _, c := range m.commits {
+			if m.authorActive(c.Email) && (q == nil || q.Match(c, m.summaries[c.Hash])) {
+				m.filteredCommits = append(m.filteredCommits, c)
+			}
+		}
+	}
+	m.cursor = 0
+	return m.loadFrame()
+}
+
+// saveFilter saves the applied filter query under a name, so it can be
+// reused as @name.
+func (m *Model) saveFilter(name string) {
+	switch {
+	case name == "":
+		m.notice = "usage: save <name>"
+	case !query.ValidName(name):
+		m.notice = fmt.Sprintf("save: %q is not a valid name (letters, digits, - and _)", name)
+	case m.queryFilter == nil:
+		m.notice = "save: no filter query applied"
+	case m.savedFilters == nil:
+		m.notice = "save: no place to save filters in this repository"
+	default:
+		if err := m.savedFilters.Set(name, m.queryFilter.String()); err != nil {
+			m.notice = err.Error()
+			return
+		}
+		m.notice = fmt.Sprintf("saved @%s: %s", name, m.queryFilter)
+	}
+}
+
+// unsaveFilter deletes a saved filter.
+func (m *Model) unsaveFilter(name string) {
+	if name == "" || m.savedFilters == nil {
+		m.notice = "usage: unsave <name>"
+		return
+	}
+	ok, err := m.savedFilters.Delete(name)
+	switch {
+	case err != nil:
+		m.notice = err.Error()
+	case !ok:
+		m.notice = fmt.Sprintf("no saved filter @%s", name)
+	default:
+		m.notice = "deleted @" + name
+	}
+}
+
+// queryCandidates are the completions of the last word of a query: fields,
+// keywords, named filters and, after "author:", author names.
+func (m *Model) queryCandidates(word string) []string {
+	neg := ""
+	if strings.HasPrefix(word, "-") {
+		neg, word = "-", word[1:]
+	}
+	var cands []string
+	if strings.HasPrefix(word, "author:") {
+		if m.registry == nil {
+			return nil // no authors until the history has loaded
+		}
+		for _, a := range m.registry.All() {
+			name := a.Name
+			if strings.ContainsRune(name, ' ') {
+				name = `"` + name + `"`
+			}
+			cands = append(cands, "author:"+name)
+		}
+	} else {
+		for _, f := range query.Fields {
+			cands = append(cands, f+":")
+		}
+		cands = append(cands, query.Keywords...)
+		for name := range m.namedFilters() {
+			cands = append(cands, "@"+name)
+		}
+	}
+	sort.Strings(cands)
+	for i := range cands {
+		cands[i] = neg + cands[i]
+	}
+	return cands
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderFuncPicker renders the list of functions declared at the current frame.
+func renderFuncPicker(m *Model) string {
+	var sb strings.Builder
+	sb.WriteString(TitleStyle.Render("ƒ Function History") + "\n")
+	sb.WriteString(strings.Repeat("─", max(m.width-6, 0)) + "\n")
+
+	if m.funcsLoading {
+		sb.WriteString(HelpStyle.Render("  parsing Go files at " + m.currentCommit().ShortHash + "…"))
+		return sb.String()
+	}
+	if len(m.funcs) == 0 {
+		sb.WriteString(HelpStyle.Render("  No Go functions declared at this commit."))
+		return sb.String()
+	}
+	if len(m.funcResults) == 0 {
+		sb.WriteString(HelpStyle.Render("  No functions match \"" + m.funcQuery + "\""))
+		return sb.String()
+	}
+
+	// Keep the selection in view.
+	visH := m.overlayRows()
+	start := 0
+	if m.funcSel >= visH {
+		start = m.funcSel - visH + 1
+	}
+	end := start + visH
+	if end > len(m.funcResults) {
+		end = len(m.funcResults)
+	}
+
+	for i := start; i < end; i++ {
+		fn := m.funcs[m.funcResults[i]]
+		line := fmt.Sprintf("  %s  %s",
+			lipgloss.NewStyle().Foreground(ColorText).Bold(true).Render(fn.String()),
+			DateStyle.Render(truncate(fmt.Sprintf("%s:%d", fn.Path, fn.Line), m.width/2)),
+		)
+		if i == m.funcSel {
+			line = SelectedStyle.Width(m.width - 6).Render(line)
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// renderFuncBar renders the function picker input.
+func renderFuncBar(m *Model) string {
+	prompt := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).Render("func:")
+	input := lipgloss.NewStyle().Foreground(ColorText).Render(m.funcQuery)
+	cursor := lipgloss.NewStyle().Foreground(ColorAccent).Render("█")
+	hits := ""
+	if !m.funcsLoading {
+		hits = HelpStyle.Render(fmt.Sprintf("  %d functions  ", len(m.funcResults))) +
+			helpLine(shortHelp("select", m.keys.InputUp, m.keys.InputDown), shortHelp("play history", m.keys.Accept))
+	}
+	return StatusBarStyle.Width(m.width).Render("  " + prompt + " " + input + cursor + hits)
+}
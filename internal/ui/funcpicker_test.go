@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFuncPickerBackspaceDeletesWholeRune(t *testing.T) {
+	m := New(".", "", 0)
+	m.state = StatePickingFunc
+	m.funcQuery = "Größe"
+
+	tm, _ := m.handleFuncKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m = tm.(Model); m.funcQuery != "Größ" || !utf8.ValidString(m.funcQuery) {
+		t.Fatalf("funcQuery = %q after backspace, want %q", m.funcQuery, "Größ")
+	}
+	tm, _ = m.handleFuncKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m = tm.(Model); m.funcQuery != "Grö" {
+		t.Fatalf("funcQuery = %q after backspace, want %q", m.funcQuery, "Grö")
+	}
+}
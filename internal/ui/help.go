@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openHelp shows the key help for the current state over the whole screen.
+func (m *Model) openHelp() {
+	m.helpFrom = m.state
+	if m.helpFrom == StatePlaying {
+		m.helpFrom = StateReady
+	}
+	m.helpScroll = 0
+	m.state = StateHelp
+}
+
+func (m Model) handleHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := &m.keys
+	switch {
+	case key.Matches(msg, k.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, k.Help, k.Cancel):
+		m.state = m.helpFrom
+	case key.Matches(msg, k.Next, k.ScrollDown):
+		m.helpScroll++
+	case key.Matches(msg, k.Prev, k.ScrollUp):
+		m.helpScroll--
+	case key.Matches(msg, k.PageDown, k.HalfPageDown):
+		m.helpScroll += m.helpHeight() / 2
+	case key.Matches(msg, k.PageUp, k.HalfPageUp):
+		m.helpScroll -= m.helpHeight() / 2
+	}
+	m.helpScroll = max(0, min(m.helpScroll, len(helpLines(&m))-m.helpHeight()))
+	return m, nil
+}
+
+// helpGroups returns the help sections for the state the overlay was opened
+// from: the bookmark list and the author picker have their own keys,
+// everything else is reached from the main view.
+func (m *Model) helpGroups() []keyGroup {
+	ctx := map[AppState]keyContext{StateBookmarks: ctxList, StatePickingAuthors: ctxPicker}[m.helpFrom]
+	var out []keyGroup
+	for _, g := range m.keys.groups() {
+		if g.ctx == ctx || ctx == ctxMain && g.ctx == ctxInput {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// helpHeight is how many lines of help fit inside the overlay's border.
+func (m *Model) helpHeight() int {
+	return max(m.height-4, 1)
+}
+
+// helpLines lays the help sections out in as many columns as fit the width,
+// balancing their heights.
+func helpLines(m *Model) []string {
+	groups := m.helpGroups()
+	keyWidth, descWidth := 0, 0
+	for _, g := range groups {
+		for _, a := range g.actions {
+			h := a.binding.Help()
+			keyWidth = max(keyWidth, lipgloss.Width(h.Key))
+			descWidth = max(descWidth, lipgloss.Width(h.Desc))
+		}
+	}
+	keyWidth = min(keyWidth, 18)
+	colWidth := keyWidth + descWidth + 5
+
+	var blocks [][]string
+	total := 0
+	for _, g := range groups {
+		block := []string{TitleStyle.Render(g.title)}
+		for _, a := range g.actions {
+			h := a.binding.Help()
+			if len(a.binding.Keys()) == 0 {
+				h.Key = "—"
+			}
+			block = append(block, "  "+KeyStyle.Width(keyWidth).Render(truncate(h.Key, keyWidth))+" "+
+				HelpStyle.Render(h.Desc))
+		}
+		block = append(block, "")
+		blocks = append(blocks, block)
+		total += len(block)
+	}
+
+	cols := max(1, min((m.width-6)/colWidth, len(blocks)))
+	target := (total + cols - 1) / cols
+	columns := make([][]string, 1, cols)
+	for _, b := range blocks {
+		cur := &columns[len(columns)-1]
+		if len(*cur) > 0 && len(*cur)+len(b) > target && len(columns) < cols {
+			columns = append(columns, nil)
+			cur = &columns[len(columns)-1]
+		}
+		*cur = append(*cur, b...)
+	}
+
+	rendered := make([]string, len(columns))
+	for i, c := range columns {
+		rendered[i] = lipgloss.NewStyle().Width(colWidth).Render(strings.Join(c, "\n"))
+	}
+	return strings.Split(lipgloss.JoinHorizontal(lipgloss.Top, rendered...), "\n")
+}
+
+// renderHelp renders the full-screen key help.
+func renderHelp(m *Model) string {
+	title := "⌨  Keys"
+	switch m.helpFrom {
+	case StateBookmarks:
+		title += " · bookmark list"
+	case StatePickingAuthors:
+		title += " · author picker"
+	}
+	header := HeaderBarStyle.Width(m.width).Render(
+		lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).Render(title) +
+			HelpStyle.Render("  remap any of these in the config file"))
+
+	lines := helpLines(m)
+	h := m.helpHeight()
+	start := max(0, min(m.helpScroll, len(lines)-h))
+	end := min(start+h, len(lines))
+	body := PaneStyle.Width(m.width - 4).Height(h).Render(strings.Join(lines[start:end], "\n"))
+
+	k := &m.keys
+	bindings := []string{
+		shortHelp("scroll", k.Next, k.Prev),
+		shortHelp("close", k.Help, k.Cancel),
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, statusBar(m, "", bindings))
+}
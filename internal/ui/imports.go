@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+// importGraphContent renders the module's internal package imports, with the
+// edges this commit added or removed highlighted.
+func importGraphContent(m *Model) string {
+	var sb strings.Builder
+
+	g := m.importGraph
+	if g == nil {
+		sb.WriteString(HelpStyle.Render("  parsing imports…"))
+		return sb.String()
+	}
+	if g.Module == "" {
+		sb.WriteString(HelpStyle.Render("  No go.mod at the repository root in this commit."))
+		return sb.String()
+	}
+
+	added, removed := g.Diff(m.importGraphPrev)
+	sb.WriteString("  " + SubtitleStyle.Render(g.Module) + "\n")
+	sb.WriteString("  " + renderFileStats(len(added), len(removed)) +
+		HelpStyle.Render(fmt.Sprintf("  edges this commit  ·  %d total", len(g.Edges))) + "\n\n")
+
+	if len(g.Edges) == 0 && len(removed) == 0 {
+		sb.WriteString(HelpStyle.Render("  No internal imports."))
+		return sb.String()
+	}
+
+	isAdded := map[git.ImportEdge]bool{}
+	for _, e := range added {
+		isAdded[e] = true
+	}
+
+	// Group current and removed edges by importing package.
+	targets := map[string][]git.ImportEdge{}
+	for _, e := range append(g.Sorted(), removed...) {
+		targets[e.From] = append(targets[e.From], e)
+	}
+	var froms []string
+	for from := range targets {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+
+	for _, from := range froms {
+		sb.WriteString("  " + lipgloss.NewStyle().Foreground(ColorText).Bold(true).
+			Render(truncate(from, m.rightWidth-8)) + "\n")
+		edges := targets[from]
+		sort.SliceStable(edges, func(i, j int) bool { return edges[i].To < edges[j].To })
+		for i, e := range edges {
+			branch := "├─→ "
+			if i == len(edges)-1 {
+				branch = "└─→ "
+			}
+			to := truncate(e.To, m.rightWidth-14)
+			switch {
+			case isAdded[e]:
+				sb.WriteString("  " + HelpStyle.Render(branch) + StatAddStyle.Render(to+"  +") + "\n")
+			case !g.Edges[e]:
+				sb.WriteString("  " + HelpStyle.Render(branch) +
+					StatDelStyle.Strikethrough(true).Render(to) + StatDelStyle.Render("  -") + "\n")
+			default:
+				sb.WriteString("  " + HelpStyle.Render(branch) +
+					lipgloss.NewStyle().Foreground(ColorSubtle).Render(to) + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxJumps bounds the jumplist; the oldest entries fall off.
+const maxJumps = 100
+
+// The jumplist remembers the frames left by big moves — g/G, search, goto,
+// bookmark jumps, filter changes and timeline clicks — so ctrl+o and ctrl+n
+// can walk back and forth through them like a browser history. Entries are
+// commit hashes, so they survive filter changes; frames the current filter
+// hides are skipped.
+
+// recordJump remembers the current frame before a big move and reports
+// whether there was one to remember. Jumping from the middle of the list
+// drops the entries ahead of it.
+func (m *Model) recordJump() bool {
+	h := m.currentCommit().Hash
+	if h == "" {
+		return false
+	}
+	jumps := m.jumps[:max(0, min(m.jumpPos, len(m.jumps)))]
+	for i := 0; i < len(jumps); i++ {
+		if jumps[i] == h {
+			jumps = append(jumps[:i], jumps[i+1:]...)
+			i--
+		}
+	}
+	jumps = append(jumps, h)
+	if len(jumps) > maxJumps {
+		jumps = jumps[len(jumps)-maxJumps:]
+	}
+	m.jumps = jumps
+	m.jumpPos = len(jumps)
+	return true
+}
+
+// bigJump moves to a frame far from the cursor, recording it in the jumplist.
+func (m *Model) bigJump(idx int) tea.Cmd {
+	m.recordJump()
+	return m.jumpTo(idx)
+}
+
+// jumpBack returns to the previous frame in the jumplist.
+func (m *Model) jumpBack() tea.Cmd {
+	if m.jumpPos == len(m.jumps) && m.recordJump() {
+		// Leaving the newest position: remember it so jumpForward can return.
+		m.jumpPos--
+	}
+	for i := m.jumpPos - 1; i >= 0; i-- {
+		if idx, ok := m.indexOf(m.jumps[i]); ok && idx != m.cursor {
+			m.jumpPos = i
+			return m.jumpTo(idx)
+		}
+	}
+	m.notice = "at the oldest jump"
+	return nil
+}
+
+// jumpForward undoes jumpBack.
+func (m *Model) jumpForward() tea.Cmd {
+	for i := m.jumpPos + 1; i < len(m.jumps); i++ {
+		if idx, ok := m.indexOf(m.jumps[i]); ok && idx != m.cursor {
+			m.jumpPos = i
+			return m.jumpTo(idx)
+		}
+	}
+	m.notice = "at the newest jump"
+	return nil
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+func TestJumpBackWithEmptyFilterThenBigJump(t *testing.T) {
+	m := New(".", "", 0)
+	m.state = StateReady
+	m.commits = []git.Commit{{Hash: "a"}, {Hash: "b"}, {Hash: "c"}}
+	m.registry = git.BuildRegistry(m.commits, git.Identities{})
+	m.filteredCommits = []git.Commit{} // a filter that leaves nothing
+
+	var tm tea.Model = m
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	m = tm.(Model)
+	if m.jumpPos < 0 {
+		t.Fatalf("jumpPos = %d after ctrl+o with no frames, want >= 0", m.jumpPos)
+	}
+
+	m.filteredCommits = nil
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	m = tm.(Model)
+	if m.cursor != 2 {
+		t.Fatalf("cursor = %d after G, want 2", m.cursor)
+	}
+	if len(m.jumps) != 1 || m.jumps[0] != "a" || m.jumpPos != 1 {
+		t.Fatalf("jumps = %v at %d, want [a] at 1", m.jumps, m.jumpPos)
+	}
+
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m = tm.(Model); m.cursor != 0 {
+		t.Fatalf("cursor = %d after ctrl+o, want 0", m.cursor)
+	}
+}
@@ -0,0 +1,380 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyMap holds every key binding. The key handlers match against it and the
+// help overlay and status bars are rendered from it, so keys remapped in the
+// config file show up everywhere.
+type keyMap struct {
+	// main view
+	Play, Faster, Slower, Reverse, MoreFrames, FewerFrames           key.Binding
+	MarkIn, MarkOut, ClearMarks, Loop, Cut, PauseRules               key.Binding
+	Realtime, LessCompression, MoreCompression                       key.Binding
+	Bookmark, Bookmarks, NextBookmark, PrevBookmark                  key.Binding
+	Next, Prev, First, Last, JumpBack, JumpForward                   key.Binding
+	SwitchPane, RepoTree, CommitList, Sort                           key.Binding
+	Layout, Zen, ShrinkFiles, GrowFiles                              key.Binding
+	ScrollDown, ScrollUp, PageDown, PageUp, HalfPageDown, HalfPageUp key.Binding
+	Top, Bottom, ToggleDir, Expand, Collapse                         key.Binding
+	Heatmap, ZoomIn, ZoomOut, GoMetrics, Imports                     key.Binding
+	Search, Palette, Filter, FuncHistory, Clear, Help, Quit          key.Binding
+
+	// text inputs: search, palette, bookmark note, function picker
+	Accept, Cancel, DeleteBack, Complete, InputUp, InputDown key.Binding
+
+	// bookmark list
+	ListUp, ListDown, ListJump, ListEdit, ListDelete, ListClose key.Binding
+
+	// author picker
+	PickerUp, PickerDown, PickerInclude, PickerExclude key.Binding
+	PickerOnly, PickerReset, PickerApply, PickerCancel key.Binding
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Play:            bind("play / pause", " "),
+		Faster:          bind("speed up", "+", "="),
+		Slower:          bind("slow down", "-"),
+		Reverse:         bind("reverse direction", "R"),
+		MoreFrames:      bind("more frames per commit", ","),
+		FewerFrames:     bind("fewer frames per commit", "."),
+		MarkIn:          bind("set in mark", "["),
+		MarkOut:         bind("set out mark", "]"),
+		ClearMarks:      bind("clear marks", "\\"),
+		Loop:            bind("loop", "L"),
+		Cut:             bind("director's cut", "d"),
+		PauseRules:      bind("pause-on-event rules", "p"),
+		Realtime:        bind("real-time playback", "r"),
+		LessCompression: bind("less history per second", "("),
+		MoreCompression: bind("more history per second", ")"),
+
+		Bookmark:     bind("bookmark frame", "b"),
+		Bookmarks:    bind("list bookmarks", "B"),
+		NextBookmark: bind("next bookmark", "n"),
+		PrevBookmark: bind("previous bookmark", "N"),
+
+		Next:        bind("next commit", "j", "down"),
+		Prev:        bind("previous commit", "k", "up"),
+		First:       bind("first commit", "g"),
+		Last:        bind("last commit", "G"),
+		JumpBack:    bind("jump back", "ctrl+o", "alt+left"),
+		JumpForward: bind("jump forward", "ctrl+n", "alt+right"),
+
+		SwitchPane:   bind("switch pane", "tab"),
+		RepoTree:     bind("repository tree", "t"),
+		CommitList:   bind("commit list", "c"),
+		Sort:         bind("cycle tree sort", "s"),
+		Layout:       bind("cycle layout", "V"),
+		Zen:          bind("zen mode", "z"),
+		ShrinkFiles:  bind("shrink file pane", "{"),
+		GrowFiles:    bind("grow file pane", "}"),
+		ScrollDown:   bind("scroll down", "J"),
+		ScrollUp:     bind("scroll up", "K"),
+		PageDown:     bind("page down", "pgdown"),
+		PageUp:       bind("page up", "pgup"),
+		HalfPageDown: bind("half page down", "ctrl+d"),
+		HalfPageUp:   bind("half page up", "ctrl+u"),
+		Top:          bind("top of pane", "home"),
+		Bottom:       bind("bottom of pane", "end"),
+		ToggleDir:    bind("toggle directory", "enter"),
+		Expand:       bind("expand directory", "l", "right"),
+		Collapse:     bind("collapse directory", "h", "left"),
+
+		Heatmap:   bind("cycle heatmap", "H"),
+		ZoomIn:    bind("zoom timeline in", ">"),
+		ZoomOut:   bind("zoom timeline out", "<"),
+		GoMetrics: bind("Go structure metrics", "M"),
+		Imports:   bind("Go import graph", "I"),
+
+		Search:      bind("search", "/"),
+		Palette:     bind("command palette", ":"),
+		Filter:      bind("pick authors", "f"),
+		FuncHistory: bind("Go function history", "F"),
+		Clear:       bind("clear filter / search", "esc"),
+		Help:        bind("help", "?"),
+		Quit:        bind("quit", "q", "ctrl+c"),
+
+		Accept:     bind("accept", "enter"),
+		Cancel:     bind("cancel", "esc"),
+		DeleteBack: bind("delete character", "backspace"),
+		Complete:   bind("complete", "tab"),
+		InputUp:    bind("previous (history, selection)", "up", "ctrl+p"),
+		InputDown:  bind("next (history, selection)", "down", "ctrl+n"),
+
+		ListUp:     bind("select previous", "k", "up"),
+		ListDown:   bind("select next", "j", "down"),
+		ListJump:   bind("jump to bookmark", "enter"),
+		ListEdit:   bind("edit note", "e"),
+		ListDelete: bind("delete bookmark", "x", "d"),
+		ListClose:  bind("close", "esc", "B", "q"),
+
+		PickerUp:      bind("select previous", "k", "up"),
+		PickerDown:    bind("select next", "j", "down"),
+		PickerInclude: bind("include author", " "),
+		PickerExclude: bind("exclude author", "x"),
+		PickerOnly:    bind("only this author", "o"),
+		PickerReset:   bind("all authors", "a"),
+		PickerApply:   bind("apply filter", "enter"),
+		PickerCancel:  bind("cancel", "esc", "q"),
+	}
+}
+
+// keyContext is where a group of bindings applies. A key may only be bound
+// once per context.
+type keyContext int
+
+const (
+	ctxMain keyContext = iota
+	ctxInput
+	ctxList
+	ctxPicker
+)
+
+// keyGroup is a titled section of the help overlay.
+type keyGroup struct {
+	title   string
+	ctx     keyContext
+	actions []keyAction
+}
+
+// keyAction is a binding with the name the config file uses for it.
+type keyAction struct {
+	name    string
+	binding *key.Binding
+}
+
+// groups returns the bindings by help section. The bindings point into k, so
+// rebinding through them changes k.
+func (k *keyMap) groups() []keyGroup {
+	return []keyGroup{
+		{"Playback", ctxMain, []keyAction{
+			{"play", &k.Play}, {"faster", &k.Faster}, {"slower", &k.Slower},
+			{"reverse", &k.Reverse}, {"more-frames", &k.MoreFrames}, {"fewer-frames", &k.FewerFrames},
+			{"realtime", &k.Realtime}, {"less-compression", &k.LessCompression},
+			{"more-compression", &k.MoreCompression},
+		}},
+		{"Range & rules", ctxMain, []keyAction{
+			{"mark-in", &k.MarkIn}, {"mark-out", &k.MarkOut}, {"clear-marks", &k.ClearMarks},
+			{"loop", &k.Loop}, {"cut", &k.Cut}, {"pause-rules", &k.PauseRules},
+		}},
+		{"Bookmarks", ctxMain, []keyAction{
+			{"bookmark", &k.Bookmark}, {"bookmarks", &k.Bookmarks},
+			{"next-bookmark", &k.NextBookmark}, {"prev-bookmark", &k.PrevBookmark},
+		}},
+		{"Navigation", ctxMain, []keyAction{
+			{"next", &k.Next}, {"prev", &k.Prev}, {"first", &k.First}, {"last", &k.Last},
+			{"jump-back", &k.JumpBack}, {"jump-forward", &k.JumpForward},
+		}},
+		{"Panes", ctxMain, []keyAction{
+			{"switch-pane", &k.SwitchPane}, {"repo-tree", &k.RepoTree},
+			{"commit-list", &k.CommitList}, {"sort", &k.Sort},
+			{"layout", &k.Layout}, {"zen", &k.Zen},
+			{"shrink-files", &k.ShrinkFiles}, {"grow-files", &k.GrowFiles},
+			{"scroll-down", &k.ScrollDown}, {"scroll-up", &k.ScrollUp},
+			{"page-down", &k.PageDown}, {"page-up", &k.PageUp},
+			{"half-page-down", &k.HalfPageDown}, {"half-page-up", &k.HalfPageUp},
+			{"top", &k.Top}, {"bottom", &k.Bottom},
+			{"toggle-dir", &k.ToggleDir}, {"expand", &k.Expand}, {"collapse", &k.Collapse},
+		}},
+		{"Views", ctxMain, []keyAction{
+			{"heatmap", &k.Heatmap}, {"zoom-in", &k.ZoomIn}, {"zoom-out", &k.ZoomOut},
+			{"go-metrics", &k.GoMetrics}, {"imports", &k.Imports},
+		}},
+		{"Search & filter", ctxMain, []keyAction{
+			{"search", &k.Search}, {"palette", &k.Palette}, {"filter", &k.Filter},
+			{"func-history", &k.FuncHistory}, {"clear", &k.Clear},
+		}},
+		{"General", ctxMain, []keyAction{
+			{"help", &k.Help}, {"quit", &k.Quit},
+		}},
+		{"Text input", ctxInput, []keyAction{
+			{"accept", &k.Accept}, {"cancel", &k.Cancel}, {"delete-back", &k.DeleteBack},
+			{"complete", &k.Complete}, {"input-up", &k.InputUp}, {"input-down", &k.InputDown},
+		}},
+		{"Bookmark list", ctxList, []keyAction{
+			{"list-up", &k.ListUp}, {"list-down", &k.ListDown}, {"list-jump", &k.ListJump},
+			{"list-edit", &k.ListEdit}, {"list-delete", &k.ListDelete}, {"list-close", &k.ListClose},
+			{"help", &k.Help},
+		}},
+		{"Author picker", ctxPicker, []keyAction{
+			{"picker-up", &k.PickerUp}, {"picker-down", &k.PickerDown},
+			{"picker-include", &k.PickerInclude}, {"picker-exclude", &k.PickerExclude},
+			{"picker-only", &k.PickerOnly}, {"picker-reset", &k.PickerReset},
+			{"picker-apply", &k.PickerApply}, {"picker-cancel", &k.PickerCancel},
+			{"help", &k.Help},
+		}},
+	}
+}
+
+// DefaultKeyHelp lists the main view's default keys for --help, one
+// "keys  description" line per action under each group's heading. The ?
+// overlay shows the keys in effect once the config has remapped them.
+func DefaultKeyHelp() []string {
+	k := defaultKeyMap()
+	var groups []keyGroup
+	width := 0
+	for _, g := range k.groups() {
+		if g.ctx != ctxMain {
+			continue
+		}
+		groups = append(groups, g)
+		for _, a := range g.actions {
+			width = max(width, lipgloss.Width(a.binding.Help().Key))
+		}
+	}
+	var lines []string
+	for _, g := range groups {
+		lines = append(lines, g.title)
+		for _, a := range g.actions {
+			h := a.binding.Help()
+			lines = append(lines, "  "+h.Key+strings.Repeat(" ", width-lipgloss.Width(h.Key)+2)+h.Desc)
+		}
+	}
+	return lines
+}
+
+// keyAliases maps the config's spelling of awkward keys to Bubble Tea's.
+var keyAliases = map[string]string{"space": " ", "backslash": "\\"}
+
+// SetKeys rebinds actions to the given keys, replacing their defaults; an
+// empty list unbinds the action. Unknown actions, keys bound twice in the same
+// context and printable keys for text input actions are errors.
+func (m *Model) SetKeys(bindings map[string][]string) error {
+	var errs []error
+	known := map[string]bool{}
+	for _, g := range m.keys.groups() {
+		for _, a := range g.actions {
+			known[a.name] = true
+			keys, ok := bindings[a.name]
+			if !ok {
+				continue
+			}
+			keys = slices.Clone(keys) // resolve aliases without touching the caller's config
+			for i, k := range keys {
+				if v, ok := keyAliases[k]; ok {
+					keys[i] = v
+				}
+			}
+			a.binding.SetKeys(keys...)
+			a.binding.SetHelp(helpKeys(keys), a.binding.Help().Desc)
+		}
+	}
+	for name := range bindings {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("keys.%s: unknown action", name))
+		}
+	}
+	return errors.Join(append(errs, m.keys.conflicts()...)...)
+}
+
+// conflicts reports keys bound to two actions of the same context, and text
+// input actions bound to keys that would be typed as text instead.
+func (k *keyMap) conflicts() []error {
+	var errs []error
+	owner := map[keyContext]map[string]string{}
+	for _, g := range k.groups() {
+		if owner[g.ctx] == nil {
+			owner[g.ctx] = map[string]string{}
+		}
+		for _, a := range g.actions {
+			for _, key := range a.binding.Keys() {
+				if prev, ok := owner[g.ctx][key]; ok && prev != a.name {
+					errs = append(errs, fmt.Errorf("keys: %q is bound to both %s and %s", key, prev, a.name))
+				}
+				owner[g.ctx][key] = a.name
+				if g.ctx == ctxInput && utf8.RuneCountInString(key) == 1 {
+					errs = append(errs, fmt.Errorf("keys.%s: %q would be typed as text", a.name, key))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func bind(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(helpKeys(keys), desc))
+}
+
+// helpKeys renders keys for the help, e.g. "j/↓".
+func helpKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = helpKey(k)
+	}
+	return strings.Join(names, "/")
+}
+
+var keyNames = map[string]string{
+	" ": "Space", "up": "↑", "down": "↓", "left": "←", "right": "→",
+	"alt+left": "Alt+←", "alt+right": "Alt+→", "pgup": "PgUp", "pgdown": "PgDn",
+	"home": "Home", "end": "End", "enter": "Enter", "esc": "Esc", "tab": "Tab",
+	"backspace": "Backspace",
+}
+
+func helpKey(k string) string {
+	if n, ok := keyNames[k]; ok {
+		return n
+	}
+	if rest, ok := strings.CutPrefix(k, "ctrl+"); ok {
+		return "Ctrl+" + strings.ToUpper(rest)
+	}
+	if rest, ok := strings.CutPrefix(k, "alt+"); ok {
+		return "Alt+" + rest
+	}
+	return k
+}
+
+// shortHelp renders one status bar entry for a set of related bindings,
+// using the first key of each, e.g. "j/k step".
+func shortHelp(desc string, bs ...key.Binding) string {
+	var keys []string
+	for _, b := range bs {
+		if ks := b.Keys(); b.Enabled() && len(ks) > 0 {
+			keys = append(keys, helpKey(ks[0]))
+		}
+	}
+	if keys == nil {
+		return ""
+	}
+	return KeyStyle.Render(strings.Join(keys, "/")) + HelpStyle.Render(" "+desc)
+}
+
+// helpLine joins status bar entries, skipping unbound ones.
+func helpLine(entries ...string) string {
+	var out []string
+	for _, e := range entries {
+		if e != "" {
+			out = append(out, e)
+		}
+	}
+	return strings.Join(out, "  ")
+}
+
+// scrollMove returns the pane scroll step msg is bound to.
+func (k *keyMap) scrollMove(msg tea.KeyMsg) (scrollMove, bool) {
+	moves := []struct {
+		b    key.Binding
+		move scrollMove
+	}{
+		{k.ScrollDown, scrollLineDown}, {k.ScrollUp, scrollLineUp},
+		{k.PageDown, scrollPageDown}, {k.PageUp, scrollPageUp},
+		{k.HalfPageDown, scrollHalfDown}, {k.HalfPageUp, scrollHalfUp},
+		{k.Top, scrollTop}, {k.Bottom, scrollBottom},
+	}
+	for _, mv := range moves {
+		if key.Matches(msg, mv.b) {
+			return mv.move, true
+		}
+	}
+	return 0, false
+}
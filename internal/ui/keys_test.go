@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDefaultKeysDoNotConflict(t *testing.T) {
+	k := defaultKeyMap()
+	if errs := k.conflicts(); len(errs) > 0 {
+		t.Fatalf("default keymap conflicts: %v", errs)
+	}
+}
+
+func TestSetKeysConflicts(t *testing.T) {
+	tests := []struct {
+		bindings map[string][]string
+		want     string // part of the error, "" = valid
+	}{
+		{map[string][]string{"play": {"p"}, "pause-rules": {"P"}}, ""},
+		// The same key in another context is fine.
+		{map[string][]string{"next": {"x"}}, ""},
+		{map[string][]string{"next-bookmark": {"j"}}, `"j" is bound to both`},
+		{map[string][]string{"accept": {"a"}}, `keys.accept: "a" would be typed as text`},
+		{map[string][]string{"plya": {"p"}}, "keys.plya: unknown action"},
+		// Unbinding an action frees its key.
+		{map[string][]string{"first": {}, "next-bookmark": {"g"}}, ""},
+	}
+	for _, tt := range tests {
+		m := New(".", "", 0)
+		err := m.SetKeys(tt.bindings)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("SetKeys(%v) = %v, want nil", tt.bindings, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("SetKeys(%v) = %v, want an error containing %q", tt.bindings, err, tt.want)
+		}
+	}
+}
+
+func TestSetKeysRebindsAndKeepsCallersConfig(t *testing.T) {
+	bindings := map[string][]string{"play": {"space", "p"}, "pause-rules": {"P"}}
+	m := New(".", "", 0)
+	if err := m.SetKeys(bindings); err != nil {
+		t.Fatal(err)
+	}
+	if got := bindings["play"]; !reflect.DeepEqual(got, []string{"space", "p"}) {
+		t.Fatalf("SetKeys changed the caller's bindings to %q", got)
+	}
+	if got := m.keys.Play.Keys(); !reflect.DeepEqual(got, []string{" ", "p"}) {
+		t.Fatalf("play is bound to %q, want space and p", got)
+	}
+	if h := m.keys.Play.Help().Key; h != "Space/p" {
+		t.Fatalf("play help = %q, want Space/p", h)
+	}
+
+	m.state = StateReady
+	tm, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	if m = tm.(Model); !m.pauseOn {
+		t.Fatal("P did not toggle the pause rules")
+	}
+}
@@ -0,0 +1,248 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/meetsoni15/gitcinema/internal/config"
+)
+
+// layoutMode arranges the file pane and the detail pane.
+type layoutMode int
+
+const (
+	layoutAuto    layoutMode = iota // pick from the terminal size
+	layoutSplit                     // side by side
+	layoutStacked                   // file pane above the detail pane
+	layoutTabbed                    // one pane at a time, switched with Tab
+)
+
+var layoutNames = []string{"auto", "split", "stacked", "tabbed"}
+
+func (l layoutMode) String() string { return layoutNames[l] }
+
+// layoutByName finds a layout mode by its name.
+func layoutByName(name string) (layoutMode, bool) {
+	for i, n := range layoutNames {
+		if n == name {
+			return layoutMode(i), true
+		}
+	}
+	return layoutAuto, false
+}
+
+// Auto layout thresholds. Below splitMinWidth columns the panes stack, and
+// when that leaves either pane fewer than stackMinRows rows they become tabs.
+const (
+	splitMinWidth = 100
+	stackMinRows  = 6
+	splitStep     = 5 // percent per split key press
+
+	headerMinHeight = 14
+)
+
+// Screen rows below the panes: the timeline.
+const timelineRows = 3
+
+// headerRows is the number of screen rows above the panes: the header and the
+// author legend, hidden in zen mode and on terminals shorter than
+// headerMinHeight rows.
+func (m *Model) headerRows() int {
+	if m.zen || m.height < headerMinHeight {
+		return 0
+	}
+	return 2
+}
+
+// statusRows is 1 when the status bar is shown. Zen mode only shows it for
+// prompts, overlays and notes.
+func (m *Model) statusRows() int {
+	if m.zen && (m.state == StateReady || m.state == StatePlaying) && statusNote(m) == "" {
+		return 0
+	}
+	return 1
+}
+
+// bodyHeight is the content height of the body between the legend and the
+// timeline, inside the pane borders.
+func (m *Model) bodyHeight() int {
+	return max(m.height-m.headerRows()-2-timelineRows-m.statusRows(), 1)
+}
+
+// overlayRows is how many list rows a full-width overlay shows below its
+// title and divider.
+func (m *Model) overlayRows() int {
+	return max(m.bodyHeight()-2, 1)
+}
+
+// effectiveLayout resolves auto to a layout that fits the terminal.
+func (m *Model) effectiveLayout() layoutMode {
+	if m.layout != layoutAuto {
+		return m.layout
+	}
+	if m.width >= splitMinWidth {
+		return layoutSplit
+	}
+	// Stacked panes share the body and spend two rows on borders.
+	if (m.bodyHeight()-2)*min(m.split, 100-m.split)/100 >= stackMinRows {
+		return layoutStacked
+	}
+	return layoutTabbed
+}
+
+// recalcLayout sizes and places the panes. Widths and heights are content
+// sizes as given to the pane styles; the borders add two to each.
+func (m *Model) recalcLayout() {
+	m.shown = m.effectiveLayout()
+	top, body := m.headerRows(), m.bodyHeight()
+	full := max(m.width-2, 1)
+
+	switch {
+	case m.zen:
+		m.leftWidth, m.leftHeight, m.leftTop = full, body, top
+		m.rightWidth, m.rightHeight, m.rightTop, m.rightLeft = full, body, top, 0
+	case m.shown == layoutSplit:
+		// left border, right border and the gap between the panes
+		m.leftWidth = max(m.width*m.split/100, 1)
+		m.rightWidth = max(m.width-m.leftWidth-5, 1)
+		m.leftHeight, m.rightHeight = body, body
+		m.leftTop, m.rightTop, m.rightLeft = top, top, m.leftWidth+3
+	case m.shown == layoutStacked:
+		inner := max(body-2, 2)
+		m.leftHeight = max(inner*m.split/100, 1)
+		m.rightHeight = max(inner-m.leftHeight, 1)
+		m.leftWidth, m.rightWidth = full, full
+		m.leftTop, m.rightTop, m.rightLeft = top, top+m.leftHeight+2, 0
+	default:
+		// the tab strip takes the first row of the body
+		h := max(body-1, 1)
+		m.leftWidth, m.leftHeight, m.leftTop = full, h, top+1
+		m.rightWidth, m.rightHeight, m.rightTop, m.rightLeft = full, h, top+1, 0
+	}
+}
+
+// paneShown reports whether a pane is on screen in the current layout.
+func (m *Model) paneShown(p ActivePane) bool {
+	switch {
+	case m.zen:
+		return p == PaneDetail
+	case m.shown == layoutTabbed:
+		return p == m.activePane
+	}
+	return true
+}
+
+// setLayout switches the layout mode, noting which layout auto picked.
+func (m *Model) setLayout(l layoutMode) {
+	m.layout = l
+	m.recalcLayout()
+	m.notice = "layout: " + l.String()
+	if l == layoutAuto {
+		m.notice += " (" + m.shown.String() + ")"
+	}
+}
+
+// resizeSplit moves the split between the panes by delta percent, within the
+// bounds the config allows.
+func (m *Model) resizeSplit(delta int) {
+	m.split = max(config.MinSplit, min(m.split+delta, config.MaxSplit))
+	m.recalcLayout()
+	if m.shown == layoutTabbed || m.zen {
+		m.notice = fmt.Sprintf("split %d%% (shown in split and stacked layouts)", m.split)
+		return
+	}
+	m.notice = fmt.Sprintf("split %d%%", m.split)
+}
+
+// toggleZen shows or hides everything but the timeline and the current frame.
+func (m *Model) toggleZen() {
+	m.zen = !m.zen
+	if m.zen {
+		m.activePane = PaneDetail
+	}
+	m.recalcLayout()
+}
+
+// renderBody renders the panes in the current layout.
+func renderBody(m *Model) string {
+	leftStyle, rightStyle := PaneStyle, PaneStyle
+	if m.activePane == PaneFiles {
+		leftStyle = ActivePaneStyle
+	} else {
+		rightStyle = ActivePaneStyle
+	}
+	left := func() string {
+		return paneBox(leftStyle, m.leftWidth, m.leftHeight, renderFileTree(m))
+	}
+	right := func() string {
+		return paneBox(rightStyle, m.rightWidth, m.rightHeight, renderRightPane(m))
+	}
+
+	switch {
+	case m.zen:
+		return paneBox(PaneStyle, m.rightWidth, m.rightHeight, renderRightPane(m))
+	case m.shown == layoutSplit:
+		return lipgloss.JoinHorizontal(lipgloss.Top, left(), " ", right())
+	case m.shown == layoutStacked:
+		return lipgloss.JoinVertical(lipgloss.Left, left(), right())
+	}
+	pane := right
+	if m.activePane == PaneFiles {
+		pane = left
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, renderTabs(m), pane())
+}
+
+// paneBox renders content in a bordered pane of the given content size. Lines
+// that are too long are cut rather than wrapped, so the pane keeps its size.
+func paneBox(style lipgloss.Style, w, h int, content string) string {
+	content = lipgloss.NewStyle().MaxWidth(max(w-2, 1)).MaxHeight(h).Render(content)
+	return style.Width(w).Height(h).Render(content)
+}
+
+// tabTitles are the labels of the tab strip, file pane first.
+func tabTitles(m *Model) [2]string {
+	files := "Files"
+	switch m.leftView {
+	case ViewRepoTree:
+		files = "Tree"
+	case ViewCommitList:
+		files = "Commits"
+	}
+	detail := "Commit"
+	if m.showImports {
+		detail = "Import Graph"
+	}
+	return [2]string{" " + files + " ", " " + detail + " "}
+}
+
+// renderTabs renders the tab strip of the tabbed layout.
+func renderTabs(m *Model) string {
+	titles := tabTitles(m)
+	var tabs []string
+	for i, t := range titles {
+		style := HelpStyle
+		if ActivePane(i) == m.activePane {
+			style = SelectedStyle
+		}
+		tabs = append(tabs, style.Render(t))
+	}
+	strip := " " + tabs[0] + " " + tabs[1]
+	if hint := "  " + shortHelp("switch", m.keys.SwitchPane); lipgloss.Width(strip+hint) <= m.width {
+		strip += hint
+	}
+	return strip
+}
+
+// tabAt reports which tab, if any, is under a screen column of the tab strip.
+func tabAt(m *Model, x int) (ActivePane, bool) {
+	titles := tabTitles(m)
+	w0, w1 := lipgloss.Width(titles[0]), lipgloss.Width(titles[1])
+	switch {
+	case x >= 1 && x < 1+w0:
+		return PaneFiles, true
+	case x >= 2+w0 && x < 2+w0+w1:
+		return PaneDetail, true
+	}
+	return PaneFiles, false
+}
@@ -5,28 +5,45 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/meetsoni15/gitcinema/internal/bookmarks"
 	"github.com/meetsoni15/gitcinema/internal/git"
+	"github.com/meetsoni15/gitcinema/internal/query"
 )
 
-// ── Speed presets ────────────────────────────────────────────────────────────
+// ── Speed ────────────────────────────────────────────────────────────────────
 
-var speedPresets = []float64{0.25, 0.5, 1.0, 2.0, 4.0}
-var defaultSpeedIdx = 2 // 1.0x
+// Speed is a free multiplier; +/- double and halve it within these bounds.
+const (
+	minSpeed     = 1.0 / 16
+	maxSpeed     = 1024.0
+	defaultSpeed = 1.0
+)
 
+// defaultInterval is how long a frame plays at 1x.
 const defaultInterval = 800 * time.Millisecond
 
+// defaultSplit is the left pane's share of the terminal width, in percent.
+const defaultSplit = 38
+
 // ── Model state ──────────────────────────────────────────────────────────────
 
 type AppState int
 
 const (
-	StateLoading   AppState = iota
-	StateReady              // normal interactive mode
-	StatePlaying            // auto-advancing
-	StateSearching          // "/" search input active
-	StateFiltering          // "f" filter author input active
+	StateLoading        AppState = iota
+	StateReady                   // normal interactive mode
+	StatePlaying                 // auto-advancing
+	StateSearching               // "/" search input active
+	StatePickingAuthors          // "f" author picker open
+	StatePickingFunc             // "F" function history picker active
+	StateNoting                  // "b" bookmark note input active
+	StateBookmarks               // "B" bookmark list open
+	StateCommand                 // ":" command palette input active
+	StateHelp                    // "?" key help overlay open
 )
 
 // ActivePane tracks which pane has focus.
@@ -40,9 +57,12 @@ const (
 // ── Messages ─────────────────────────────────────────────────────────────────
 
 type loadDoneMsg struct {
-	commits  []git.Commit
-	registry *git.Registry
-	err      error
+	commits     []git.Commit
+	registry    *git.Registry
+	authorFirst map[string]string   // hash of each author's first commit, by email
+	tags        map[string][]string // tag names by commit hash
+	branches    []string
+	err         error
 }
 
 type diffLoadedMsg struct {
@@ -51,9 +71,48 @@ type diffLoadedMsg struct {
 	err   error
 }
 
-type playTickMsg struct{}
+type funcsLoadedMsg struct {
+	gen   int
+	hash  string
+	funcs []git.FuncRef
+	err   error
+}
+
+type funcHistoryMsg struct {
+	gen    int
+	fn     git.FuncRef
+	hashes map[string]bool
+	err    error
+}
+
+type goMetricsMsg struct {
+	hash string
+	cur  *git.GoMetrics
+	prev *git.GoMetrics
+	err  error
+}
+
+type importGraphMsg struct {
+	hash string
+	cur  *git.ImportGraph
+	prev *git.ImportGraph
+	err  error
+}
+
+type repoFilesMsg struct {
+	hash  string
+	paths []string
+	err   error
+}
+
 type spinnerTickMsg struct{}
 
+type summariesMsg struct {
+	branch    string // branch the summaries were loaded from
+	summaries map[string]git.ChangeSummary
+	err       error
+}
+
 // ── Model ─────────────────────────────────────────────────────────────────────
 
 // Model is the root Bubble Tea model for gitcinema.
@@ -64,36 +123,146 @@ type Model struct {
 	maxCount int
 	commits  []git.Commit
 	registry *git.Registry
+	tags     map[string][]string // tag names by commit hash
+	branches []string
+
+	identities git.Identities // author display overrides from the config
+
+	authorFirst      map[string]string            // hash of each author's first commit, by email
+	summaries        map[string]git.ChangeSummary // change sizes by hash, nil until loaded
+	summariesLoading bool
 
 	// navigation
 	cursor     int
-	fileScroll int
 	activePane ActivePane
+	leftVP     viewport.Model // scrolls the left pane below its title
+	rightVP    viewport.Model // scrolls the right pane below its title
+	hoverIdx   int            // commit under the pointer on the scrubber, -1 = none
+	scrubbing  bool           // dragging the scrubber with the left button held
+
+	// left pane
+	leftView     LeftView
+	repoFiles    []string        // every path at the current frame, loaded for ViewRepoTree
+	repoFilesErr error           // why repoFiles failed to load
+	fileTree     *treeNode       // tree shown in the left pane, rebuilt per frame
+	treeExpanded map[string]bool // directories the user expanded (true) or collapsed (false)
+	treeSel      int             // selected row of fileTree
+	treeSort     TreeSort
 
 	// playback
 	state    AppState
 	playing  bool
-	speedIdx int
 	speed    float64
+	interval time.Duration // frame length at 1x
+	reverse  bool          // play towards the first commit
+	fpcIdx   int           // index into framesPerCommitPresets
+	stepAcc  float64       // fractional commits carried over between frames
+
+	// A-B range
+	markIn  string // hash of the frame playback starts from, "" = first
+	markOut string // hash of the frame playback ends at, "" = last
+	loop    bool   // restart at the in mark instead of stopping at the out mark
+
+	// director's cut
+	cutRules CutRules
+	cutOn    bool
+	cut      map[string]string // hash → why the frame is cut
+	cutGen   int               // bumped whenever cut is rebuilt
+
+	// pause-on-event rules
+	pauseRules PauseRules
+	pauseOn    bool
+	pausedBy   string // rule that paused playback on the current frame
+
+	// bookmarks
+	bookmarks *bookmarks.Store // nil when bookmarks are unavailable
+	noteHash  string           // commit the note input is for
+	noteInput string
+	bmSel     int // selected row of the bookmark list
+
+	// jumplist, see recordJump
+	jumps   []string // hashes of frames left by big moves, oldest first
+	jumpPos int      // current position in jumps, len(jumps) at the newest
+
+	// command palette
+	cmdInput    string
+	cmdHistory  []string // commands run this session, oldest first
+	cmdHistIdx  int      // position while browsing cmdHistory, len = new input
+	completions []string // candidates the last Tab found
+	notice      string   // one-off message in the status bar, cleared by the next key
+
+	// real-time playback
+	realtime       bool          // frame duration follows the time between commits
+	compressionIdx int           // index into compressionPresets
+	playGen        int           // bumped on every scheduled frame, see playTickMsg
+	frameStart     time.Time     // when the current frame started playing
+	frameDur       time.Duration // how long the current frame plays
 
 	// diff
 	currentDiff *git.CommitStats
 	loadingDiff bool
 
+	// Go structure metrics
+	showGoMetrics bool
+	goMetrics     *git.GoMetrics // current frame
+	goMetricsPrev *git.GoMetrics // the commit's parent, nil for a root commit
+
+	// import graph
+	showImports     bool
+	importGraph     *git.ImportGraph // current frame
+	importGraphPrev *git.ImportGraph // the commit's parent, nil for a root commit
+
 	// search
 	searchQuery   string
-	searchResults []int // indices into commits
+	searchResults []int // indices into the active commits
+	searchErr     error // why the query does not parse
+	searchPending bool  // the query waits for the change summaries
 
 	// filter
-	filterQuery     string
-	filterAuthor    string
-	filteredCommits []git.Commit // nil = no filter
-
-	// layout
-	width      int
-	height     int
-	leftWidth  int
-	rightWidth int
+	filteredCommits []git.Commit          // nil = no filter
+	authorMarks     map[string]authorMark // applied author filter by email, nil = none
+	pickList        []authorCount         // author picker rows, most commits first
+	pickMarks       map[string]authorMark // marks being edited in the picker
+	pickSel         int                   // selected row of the author picker
+	startAuthor     string                // --author filter, applied once the history loads
+	queryFilter     *query.Query          // applied filter query, nil = none
+	filterPending   bool                  // queryFilter waits for the change summaries
+	configFilters   map[string]string     // named filters from the config
+	savedFilters    *query.Saved          // named filters saved from the palette, nil = none
+
+	// function history
+	funcQuery    string
+	funcs        []git.FuncRef // functions declared at the frame the picker opened on
+	funcResults  []int         // indices into funcs
+	funcSel      int           // index into funcResults
+	funcsLoading bool
+	funcGen      int          // bumped on every function lookup, so stale results are dropped
+	funcFilter   *git.FuncRef // function whose history is playing, nil = none
+
+	// key bindings and the help overlay
+	keys       keyMap
+	helpFrom   AppState // state the help overlay returns to
+	helpScroll int      // first line of the help overlay shown
+
+	// layout, see recalcLayout
+	width       int
+	height      int
+	layout      layoutMode // chosen layout, possibly auto
+	shown       layoutMode // layout on screen, never auto
+	zen         bool       // only the timeline and the current frame
+	split       int        // file pane share in percent: width side by side, height stacked
+	leftWidth   int
+	leftHeight  int
+	leftTop     int // screen row of the file pane's top border
+	rightWidth  int
+	rightHeight int
+	rightTop    int // screen row of the detail pane's top border
+	rightLeft   int // screen column of the detail pane's left border
+
+	// timeline
+	heatMode HeatMode
+	zoom     ZoomLevel
+	strip    *stripCache // last scrubber strip, shared by copies of the model
 
 	// spinner
 	spinnerFrame int
@@ -109,13 +278,30 @@ func New(root, branch string, maxCount int) Model {
 		branch:   branch,
 		maxCount: maxCount,
 		state:    StateLoading,
-		speedIdx: defaultSpeedIdx,
-		speed:    speedPresets[defaultSpeedIdx],
+		speed:    defaultSpeed,
+		interval: defaultInterval,
+		fpcIdx:   defaultFPCIdx,
+		split:    defaultSplit,
+		keys:     defaultKeyMap(),
+
+		compressionIdx: defaultCompressionIdx,
+
+		treeExpanded: map[string]bool{},
+		leftVP:       viewport.New(0, 0),
+		rightVP:      viewport.New(0, 0),
+		hoverIdx:     -1,
+		strip:        &stripCache{},
+		cutRules:     DefaultCutRules(),
+		pauseRules:   DefaultPauseRules(),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.loadHistory(), spinnerTick())
+	cmds := []tea.Cmd{m.loadHistory(), spinnerTick()}
+	if m.summariesLoading {
+		cmds = append(cmds, m.loadSummaries())
+	}
+	return tea.Batch(cmds...)
 }
 
 // ── Commands ──────────────────────────────────────────────────────────────────
@@ -126,8 +312,17 @@ func (m Model) loadHistory() tea.Cmd {
 		if err != nil {
 			return loadDoneMsg{err: err}
 		}
-		registry := git.BuildRegistry(commits)
-		return loadDoneMsg{commits: commits, registry: registry}
+		registry := git.BuildRegistry(commits, m.identities)
+		first := firstCommits(commits)
+		if m.maxCount > 0 && len(commits) >= m.maxCount {
+			// The oldest loaded commit of an author need not be their first.
+			if first, err = git.FirstCommits(m.root, m.branch); err != nil {
+				first = nil // the new-author rule stays quiet
+			}
+		}
+		tags, _ := git.LoadTags(m.root)         // tag markers are optional
+		branches, _ := git.ListBranches(m.root) // only used for completion
+		return loadDoneMsg{commits: commits, registry: registry, authorFirst: first, tags: tags, branches: branches}
 	}
 }
 
@@ -138,9 +333,76 @@ func (m Model) loadDiff(hash string) tea.Cmd {
 	}
 }
 
-func playTick(speed float64) tea.Cmd {
-	dur := time.Duration(float64(defaultInterval) / speed)
-	return tea.Tick(dur, func(t time.Time) tea.Msg { return playTickMsg{} })
+func (m *Model) loadFuncs(hash string) tea.Cmd {
+	m.funcGen++
+	m.funcsLoading = true
+	root, gen := m.root, m.funcGen
+	return func() tea.Msg {
+		funcs, err := git.GoFuncsAt(root, hash)
+		return funcsLoadedMsg{gen: gen, hash: hash, funcs: funcs, err: err}
+	}
+}
+
+func (m *Model) traceFunc(fn git.FuncRef) tea.Cmd {
+	m.funcGen++
+	m.funcsLoading = true
+	root, branch, gen := m.root, m.branch, m.funcGen
+	return func() tea.Msg {
+		hashes, err := git.FuncHistory(root, branch, fn)
+		return funcHistoryMsg{gen: gen, fn: fn, hashes: hashes, err: err}
+	}
+}
+
+// cancelFuncs drops the results of any function lookup still running.
+func (m *Model) cancelFuncs() {
+	m.funcGen++
+	m.funcsLoading = false
+}
+
+func (m Model) loadGoMetrics(hash, parent string) tea.Cmd {
+	return func() tea.Msg {
+		cur, err := git.LoadGoMetrics(m.root, hash)
+		if err != nil || parent == "" {
+			return goMetricsMsg{hash: hash, cur: cur, err: err}
+		}
+		prev, err := git.LoadGoMetrics(m.root, parent)
+		return goMetricsMsg{hash: hash, cur: cur, prev: prev, err: err}
+	}
+}
+
+func (m Model) loadImportGraph(hash, parent string) tea.Cmd {
+	return func() tea.Msg {
+		cur, err := git.LoadImportGraph(m.root, hash)
+		if err != nil || parent == "" {
+			return importGraphMsg{hash: hash, cur: cur, err: err}
+		}
+		prev, err := git.LoadImportGraph(m.root, parent)
+		return importGraphMsg{hash: hash, cur: cur, prev: prev, err: err}
+	}
+}
+
+func (m Model) loadSummaries() tea.Cmd {
+	return func() tea.Msg {
+		s, err := git.LoadChangeSummaries(m.root, m.branch, m.maxCount)
+		return summariesMsg{branch: m.branch, summaries: s, err: err}
+	}
+}
+
+// ensureSummaries starts loading the change summaries of the whole history
+// unless they are loaded or on their way.
+func (m *Model) ensureSummaries() tea.Cmd {
+	if m.summaries != nil || m.summariesLoading {
+		return nil
+	}
+	m.summariesLoading = true
+	return m.loadSummaries()
+}
+
+func (m Model) loadRepoFiles(hash string) tea.Cmd {
+	return func() tea.Msg {
+		paths, err := git.ListFiles(m.root, hash)
+		return repoFilesMsg{hash: hash, paths: paths, err: err}
+	}
 }
 
 func spinnerTick() tea.Cmd {
@@ -150,11 +412,36 @@ func spinnerTick() tea.Cmd {
 // ── Update ────────────────────────────────────────────────────────────────────
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if nm, ok := next.(Model); ok {
+		nm.recalcLayout()
+		if !panesStatic(msg, m.scrubbing) {
+			nm.syncViewports()
+		}
+		return nm, cmd
+	}
+	return next, cmd
+}
+
+// panesStatic reports whether msg leaves the panes as they were, so their
+// content is not rendered again: moving the pointer without dragging the
+// scrubber only moves its hover tooltip, and the spinner only turns while
+// the history loads.
+func panesStatic(msg tea.Msg, scrubbing bool) bool {
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return msg.Action == tea.MouseActionMotion && !scrubbing
+	case spinnerTickMsg:
+		return true
+	}
+	return false
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.recalcLayout()
 
 	case spinnerTickMsg:
 		m.spinnerFrame = (m.spinnerFrame + 1) % len(spinnerFrames)
@@ -170,22 +457,140 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.commits = msg.commits
 		m.registry = msg.registry
+		m.tags = msg.tags
+		m.branches = msg.branches
 		m.state = StateReady
-		if len(m.commits) > 0 {
-			return m, m.loadDiff(m.commits[0].Hash)
+		m.authorFirst = msg.authorFirst
+		if m.summaries != nil {
+			m.applyCut()
+		}
+		if q := m.startAuthor; q != "" {
+			m.startAuthor = ""
+			if cmd := m.filterByAuthor(q); cmd != nil {
+				return m, cmd
+			}
+		}
+		if m.queryFilter != nil {
+			return m, m.refilter()
+		}
+		return m, m.loadFrame()
+
+	case gotoMsg:
+		return m, m.gotoResolved(msg)
+
+	case branchMsg:
+		return m, m.switchBranch(msg)
+
+	case summariesMsg:
+		if msg.branch != m.branch {
+			return m, nil // loaded before a branch switch
+		}
+		m.summariesLoading = false
+		if msg.err != nil {
+			// Only the cut, size rules and size queries need the summaries:
+			// turn those off and keep playing.
+			m.notice = "loading change sizes: " + msg.err.Error()
+			if m.cutOn {
+				m.cutOn = false
+				m.notice += "; director's cut off"
+			}
+			if m.searchPending {
+				m.searchPending, m.searchErr = false, msg.err
+			}
+			if m.filterPending {
+				m.queryFilter = nil
+				m.notice += "; filter query dropped"
+				return m, m.refilter()
+			}
+			return m, nil
+		}
+		m.summaries = msg.summaries
+		m.applyCut()
+		if m.searchPending {
+			m.runSearch()
+		}
+		if m.filterPending {
+			return m, m.refilter()
 		}
 
 	case diffLoadedMsg:
 		m.loadingDiff = false
 		if msg.err == nil && msg.hash == m.currentCommit().Hash {
 			m.currentDiff = msg.stats
+			m.rebuildTree()
+		}
+
+	case repoFilesMsg:
+		if msg.hash != m.currentCommit().Hash {
+			break
 		}
+		if msg.err != nil {
+			m.repoFilesErr = msg.err
+			break
+		}
+		m.repoFiles, m.repoFilesErr = msg.paths, nil
+		m.rebuildTree()
+
+	case goMetricsMsg:
+		if msg.err == nil && msg.hash == m.currentCommit().Hash {
+			m.goMetrics = msg.cur
+			m.goMetricsPrev = msg.prev
+		}
+
+	case importGraphMsg:
+		if msg.err == nil && msg.hash == m.currentCommit().Hash {
+			m.importGraph = msg.cur
+			m.importGraphPrev = msg.prev
+		}
+
+	case funcsLoadedMsg:
+		if msg.gen != m.funcGen || m.state != StatePickingFunc {
+			return m, nil
+		}
+		m.funcsLoading = false
+		if msg.err != nil {
+			m.notice = "function history: " + msg.err.Error()
+			m.state = StateReady
+			return m, nil
+		}
+		m.funcs = msg.funcs
+		m.runFuncSearch()
+
+	case funcHistoryMsg:
+		if msg.gen != m.funcGen {
+			return m, nil
+		}
+		m.funcsLoading = false
+		if msg.err != nil {
+			m.notice = "function history: " + msg.err.Error()
+			return m, nil
+		}
+		m.recordJump()
+		fn := msg.fn
+		m.funcFilter = &fn
+		m.authorMarks, m.queryFilter, m.filterPending = nil, nil, false
+		m.filteredCommits = []git.Commit{}
+		for _, c := range m.commits {
+			if msg.hashes[c.Hash] {
+				m.filteredCommits = append(m.filteredCommits, c)
+			}
+		}
+		m.cursor = 0
+		return m, m.loadFrame()
 
 	case playTickMsg:
-		if m.playing {
-			return m.stepForward()
+		if m.playing && msg.gen == m.playGen {
+			return m.advance()
 		}
 
+	case clockTickMsg:
+		if m.playing && m.realtime && msg.gen == m.playGen {
+			return m, clockTick(msg.gen)
+		}
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.KeyMsg:
 		return m.handleKey(msg)
 	}
@@ -198,171 +603,383 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.state == StateSearching {
 		return m.handleSearchKey(msg)
 	}
-	// ── Filter mode ──────────────────────────────────────────────────────────
-	if m.state == StateFiltering {
-		return m.handleFilterKey(msg)
+	// ── Author picker ────────────────────────────────────────────────────────
+	if m.state == StatePickingAuthors {
+		return m.handleAuthorPickerKey(msg)
+	}
+	// ── Function picker ──────────────────────────────────────────────────────
+	if m.state == StatePickingFunc {
+		return m.handleFuncKey(msg)
+	}
+	// ── Bookmarks ────────────────────────────────────────────────────────────
+	if m.state == StateNoting {
+		return m.handleNoteKey(msg)
+	}
+	if m.state == StateBookmarks {
+		return m.handleBookmarkKey(msg)
+	}
+	// ── Command palette ──────────────────────────────────────────────────────
+	if m.state == StateCommand {
+		return m.handleCommandKey(msg)
+	}
+	// ── Help ─────────────────────────────────────────────────────────────────
+	if m.state == StateHelp {
+		return m.handleHelpKey(msg)
+	}
+	m.notice = ""
+
+	k := &m.keys
+	if mv, ok := k.scrollMove(msg); ok {
+		if m.activePane == PaneFiles && m.leftView == ViewCommitList {
+			return m, m.scrollCommitList(mv)
+		}
+		m.scrollPane(mv)
+		return m, nil
 	}
 
-	switch msg.String() {
-	case "q", "ctrl+c":
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
 
-	case "j", "down":
+	case key.Matches(msg, k.Help):
+		m.stopPlaying()
+		m.openHelp()
+
+	case key.Matches(msg, k.Next):
 		m, cmd := m.stepForward()
 		return m, cmd
 
-	case "k", "up":
+	case key.Matches(msg, k.Prev):
 		m, cmd := m.stepBackward()
 		return m, cmd
 
-	case "g":
-		m.cursor = 0
-		m.currentDiff = nil
-		m.loadingDiff = true
-		return m, m.loadDiff(m.currentCommit().Hash)
-
-	case "G":
-		m.cursor = len(m.activeCommits()) - 1
-		m.currentDiff = nil
-		m.loadingDiff = true
-		return m, m.loadDiff(m.currentCommit().Hash)
-
-	case " ":
-		m.playing = !m.playing
+	case key.Matches(msg, k.First):
+		return m, m.bigJump(0)
+
+	case key.Matches(msg, k.Last):
+		return m, m.bigJump(len(m.activeCommits()) - 1)
+
+	case key.Matches(msg, k.JumpBack):
+		return m, m.jumpBack()
+
+	case key.Matches(msg, k.JumpForward):
+		return m, m.jumpForward()
+
+	case key.Matches(msg, k.Play):
+		return m, m.togglePlay()
+
+	case key.Matches(msg, k.MarkIn):
+		m.setMark(true)
+
+	case key.Matches(msg, k.MarkOut):
+		m.setMark(false)
+
+	case key.Matches(msg, k.ClearMarks):
+		m.markIn, m.markOut = "", ""
+
+	case key.Matches(msg, k.Loop):
+		m.loop = !m.loop
+
+	case key.Matches(msg, k.Cut):
+		return m, m.toggleCut()
+
+	case key.Matches(msg, k.PauseRules):
+		return m, m.togglePauses()
+
+	case key.Matches(msg, k.Bookmark):
+		m.stopPlaying()
+		m.startNote(m.currentCommit().Hash)
+
+	case key.Matches(msg, k.Bookmarks):
+		if m.bookmarks != nil {
+			m.stopPlaying()
+			m.bmSel = 0
+			m.state = StateBookmarks
+		}
+
+	case key.Matches(msg, k.Palette):
+		m.stopPlaying()
+		m.openPalette()
+
+	case key.Matches(msg, k.NextBookmark):
+		return m, m.jumpBookmark(1)
+
+	case key.Matches(msg, k.PrevBookmark):
+		return m, m.jumpBookmark(-1)
+
+	case key.Matches(msg, k.Faster):
+		m.SetSpeed(m.speed * 2)
+
+	case key.Matches(msg, k.Slower):
+		m.SetSpeed(m.speed / 2)
+
+	case key.Matches(msg, k.Reverse):
+		m.reverse = !m.reverse
+
+	case key.Matches(msg, k.FewerFrames):
+		if m.fpcIdx < len(framesPerCommitPresets)-1 {
+			m.fpcIdx++
+		}
+
+	case key.Matches(msg, k.MoreFrames):
+		if m.fpcIdx > 0 {
+			m.fpcIdx--
+		}
+
+	case key.Matches(msg, k.Realtime):
+		m.realtime = !m.realtime
 		if m.playing {
-			m.state = StatePlaying
-			return m, playTick(m.speed)
+			return m, m.schedulePlay()
 		}
-		m.state = StateReady
 
-	case "+", "=":
-		if m.speedIdx < len(speedPresets)-1 {
-			m.speedIdx++
-			m.speed = speedPresets[m.speedIdx]
+	case key.Matches(msg, k.MoreCompression):
+		if m.compressionIdx < len(compressionPresets)-1 {
+			m.compressionIdx++
 		}
 
-	case "-":
-		if m.speedIdx > 0 {
-			m.speedIdx--
-			m.speed = speedPresets[m.speedIdx]
+	case key.Matches(msg, k.LessCompression):
+		if m.compressionIdx > 0 {
+			m.compressionIdx--
 		}
 
-	case "tab":
+	case key.Matches(msg, k.SwitchPane):
+		if m.zen {
+			break
+		}
 		if m.activePane == PaneFiles {
 			m.activePane = PaneDetail
 		} else {
 			m.activePane = PaneFiles
 		}
 
-	case "/":
+	case key.Matches(msg, k.Layout):
+		m.setLayout((m.layout + 1) % layoutMode(len(layoutNames)))
+
+	case key.Matches(msg, k.Zen):
+		m.toggleZen()
+
+	case key.Matches(msg, k.ShrinkFiles):
+		m.resizeSplit(-splitStep)
+
+	case key.Matches(msg, k.GrowFiles):
+		m.resizeSplit(splitStep)
+
+	case key.Matches(msg, k.RepoTree):
+		m.treeSel = 0
+		m.leftVP.GotoTop()
+		if m.leftView == ViewRepoTree {
+			m.leftView = ViewChangedFiles
+			m.rebuildTree()
+			return m, nil
+		}
+		m.leftView = ViewRepoTree
+		m.repoFilesErr = nil
+		if c := m.currentCommit(); c.Hash != "" {
+			return m, m.loadRepoFiles(c.Hash)
+		}
+
+	case key.Matches(msg, k.CommitList):
+		m.treeSel = 0
+		m.leftVP.GotoTop()
+		if m.leftView == ViewCommitList {
+			m.leftView = ViewChangedFiles
+		} else {
+			m.leftView = ViewCommitList
+		}
+		m.rebuildTree()
+
+	case key.Matches(msg, k.Sort):
+		m.treeSort = (m.treeSort + 1) % 3
+		m.rebuildTree()
+
+	case key.Matches(msg, k.ToggleDir):
+		if n := m.selectedNode(); n != nil && n.dir {
+			m.treeExpanded[n.path] = !isExpanded(n, m.treeExpanded)
+		}
+
+	case key.Matches(msg, k.Expand):
+		if n := m.selectedNode(); n != nil && n.dir {
+			m.treeExpanded[n.path] = true
+		}
+
+	case key.Matches(msg, k.Collapse):
+		m.collapseSelected()
+
+	case key.Matches(msg, k.Search):
 		m.stopPlaying()
 		m.state = StateSearching
 		m.searchQuery = ""
 		m.searchResults = nil
+		m.searchErr = nil
 
-	case "f":
+	case key.Matches(msg, k.Filter):
+		if m.registry == nil {
+			break // no authors until the history has loaded
+		}
+		m.stopPlaying()
+		m.openAuthorPicker()
+
+	case key.Matches(msg, k.FuncHistory):
 		m.stopPlaying()
-		m.state = StateFiltering
-		m.filterQuery = ""
+		m.state = StatePickingFunc
+		m.funcQuery = ""
+		m.funcs = nil
+		m.funcResults = nil
+		m.funcSel = 0
+		return m, m.loadFuncs(m.currentCommit().Hash)
+
+	case key.Matches(msg, k.ZoomIn):
+		if m.zoom < ZoomDay {
+			m.zoom++
+		}
+
+	case key.Matches(msg, k.ZoomOut):
+		if m.zoom > ZoomAll {
+			m.zoom--
+		}
+
+	case key.Matches(msg, k.Heatmap):
+		m.heatMode = (m.heatMode + 1) % 2
 
-	case "esc":
+	case key.Matches(msg, k.GoMetrics):
+		m.showGoMetrics = !m.showGoMetrics
+		if m.showGoMetrics {
+			c := m.currentCommit()
+			if c.Hash != "" {
+				return m, m.loadGoMetrics(c.Hash, c.Parent())
+			}
+		}
+
+	case key.Matches(msg, k.Imports):
+		m.showImports = !m.showImports
+		if m.showImports {
+			c := m.currentCommit()
+			if c.Hash != "" {
+				return m, m.loadImportGraph(c.Hash, c.Parent())
+			}
+		}
+
+	case key.Matches(msg, k.Clear):
 		m.stopPlaying()
+		m.recordJump()
+		m.cancelFuncs()
 		m.state = StateReady
-		m.filterAuthor = ""
+		m.authorMarks = nil
+		m.queryFilter, m.filterPending = nil, false
+		m.funcFilter = nil
 		m.filteredCommits = nil
 		m.searchQuery = ""
 		m.searchResults = nil
 		m.cursor = 0
-		m.currentDiff = nil
-		m.loadingDiff = true
-		if len(m.commits) > 0 {
-			return m, m.loadDiff(m.commits[0].Hash)
-		}
+		return m, m.loadFrame()
 	}
 
 	return m, nil
 }
 
 func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
 		m.state = StateReady
 		m.searchQuery = ""
 		m.searchResults = nil
-	case "enter":
+	case key.Matches(msg, m.keys.Accept):
 		if len(m.searchResults) > 0 {
+			m.recordJump()
 			m.cursor = m.searchResults[0]
 			m.state = StateReady
-			m.currentDiff = nil
-			m.loadingDiff = true
-			return m, m.loadDiff(m.currentCommit().Hash)
+			return m, m.loadFrame()
 		}
 		m.state = StateReady
-	case "backspace":
-		if len(m.searchQuery) > 0 {
-			m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
-			m.runSearch()
+	case key.Matches(msg, m.keys.DeleteBack):
+		if r := []rune(m.searchQuery); len(r) > 0 {
+			m.searchQuery = string(r[:len(r)-1])
+			return m, m.runSearch()
 		}
 	default:
 		if len(msg.Runes) > 0 {
 			m.searchQuery += string(msg.Runes)
-			m.runSearch()
+			return m, m.runSearch()
 		}
 	}
 	return m, nil
 }
 
-func (m *Model) runSearch() {
-	m.searchResults = nil
-	if m.searchQuery == "" {
-		return
+// runSearch runs the search query over the active commits. A query on commit
+// sizes starts loading the change summaries and runs again once they arrive.
+func (m *Model) runSearch() tea.Cmd {
+	m.searchResults, m.searchErr, m.searchPending = nil, nil, false
+	if strings.TrimSpace(m.searchQuery) == "" {
+		return nil
+	}
+	q, err := m.parseQuery(m.searchQuery)
+	if err != nil {
+		m.searchErr = err
+		return nil
 	}
-	q := strings.ToLower(m.searchQuery)
-	for i, c := range m.commits {
-		if strings.Contains(strings.ToLower(c.Subject), q) ||
-			strings.Contains(strings.ToLower(c.Author), q) ||
-			strings.Contains(c.ShortHash, q) {
+	if q.NeedsSummaries() && m.summaries == nil {
+		m.searchPending = true
+		return m.ensureSummaries()
+	}
+	for i, c := range m.activeCommits() {
+		if q.Match(c, m.summaries[c.Hash]) {
 			m.searchResults = append(m.searchResults, i)
 		}
 	}
+	return nil
 }
 
-func (m Model) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+func (m Model) handleFuncKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.cancelFuncs()
 		m.state = StateReady
-		m.filterQuery = ""
-	case "enter":
-		m.filterAuthor = m.filterQuery
-		m.filteredCommits = nil
-		if m.filterAuthor != "" {
-			q := strings.ToLower(m.filterAuthor)
-			for _, c := range m.commits {
-				if strings.Contains(strings.ToLower(c.Author), q) ||
-					strings.Contains(strings.ToLower(c.Email), q) {
-					m.filteredCommits = append(m.filteredCommits, c)
-				}
-			}
+		m.funcQuery = ""
+		m.funcs = nil
+		m.funcResults = nil
+	case key.Matches(msg, m.keys.Accept):
+		if m.funcSel < len(m.funcResults) {
+			fn := m.funcs[m.funcResults[m.funcSel]]
+			m.state = StateReady
+			return m, m.traceFunc(fn)
 		}
-		m.cursor = 0
-		m.currentDiff = nil
-		m.loadingDiff = true
-		m.state = StateReady
-		if len(m.activeCommits()) > 0 {
-			return m, m.loadDiff(m.activeCommits()[0].Hash)
+	case key.Matches(msg, m.keys.InputUp):
+		if m.funcSel > 0 {
+			m.funcSel--
+		}
+	case key.Matches(msg, m.keys.InputDown):
+		if m.funcSel < len(m.funcResults)-1 {
+			m.funcSel++
 		}
-	case "backspace":
-		if len(m.filterQuery) > 0 {
-			m.filterQuery = m.filterQuery[:len(m.filterQuery)-1]
+	case key.Matches(msg, m.keys.DeleteBack):
+		if r := []rune(m.funcQuery); len(r) > 0 {
+			m.funcQuery = string(r[:len(r)-1])
+			m.runFuncSearch()
 		}
 	default:
 		if len(msg.Runes) > 0 {
-			m.filterQuery += string(msg.Runes)
+			m.funcQuery += string(msg.Runes)
+			m.runFuncSearch()
 		}
 	}
 	return m, nil
 }
 
+// runFuncSearch narrows the picker to functions whose name or path contains
+// the query.
+func (m *Model) runFuncSearch() {
+	m.funcResults = nil
+	m.funcSel = 0
+	q := strings.ToLower(m.funcQuery)
+	for i, fn := range m.funcs {
+		if q == "" ||
+			strings.Contains(strings.ToLower(fn.String()), q) ||
+			strings.Contains(strings.ToLower(fn.Path), q) {
+			m.funcResults = append(m.funcResults, i)
+		}
+	}
+}
+
 // activeCommits returns filtered commits if a filter is active, otherwise all commits.
 func (m *Model) activeCommits() []git.Commit {
 	if m.filteredCommits != nil {
@@ -379,18 +996,56 @@ func (m *Model) currentCommit() git.Commit {
 	return ac[m.cursor]
 }
 
+// loadFrame resets per-frame state after the cursor moved and returns the
+// commands that load what the panes show for the new frame.
+func (m *Model) loadFrame() tea.Cmd {
+	m.resetFrame()
+	c := m.currentCommit()
+	if c.Hash == "" {
+		m.loadingDiff = false
+		return nil
+	}
+	m.loadingDiff = true
+	cmds := []tea.Cmd{m.loadDiff(c.Hash)}
+	if m.showGoMetrics {
+		cmds = append(cmds, m.loadGoMetrics(c.Hash, c.Parent()))
+	}
+	if m.showImports {
+		cmds = append(cmds, m.loadImportGraph(c.Hash, c.Parent()))
+	}
+	if m.leftView == ViewRepoTree {
+		cmds = append(cmds, m.loadRepoFiles(c.Hash))
+	}
+	return tea.Batch(cmds...)
+}
+
+// jumpTo moves the cursor to the given frame and loads it.
+func (m *Model) jumpTo(idx int) tea.Cmd {
+	m.cursor = max(0, min(idx, len(m.activeCommits())-1))
+	return m.loadFrame()
+}
+
+// resetFrame clears everything loaded for the previous frame.
+func (m *Model) resetFrame() {
+	m.pausedBy = ""
+	m.currentDiff = nil
+	m.loadingDiff = true
+	m.goMetrics = nil
+	m.goMetricsPrev = nil
+	m.importGraph = nil
+	m.importGraphPrev = nil
+	m.repoFiles, m.repoFilesErr = nil, nil
+	m.fileTree = nil
+	m.treeSel = 0
+	m.leftVP.GotoTop()
+	m.rightVP.GotoTop()
+}
+
 func (m Model) stepForward() (Model, tea.Cmd) {
 	ac := m.activeCommits()
 	if m.cursor < len(ac)-1 {
 		m.cursor++
-		m.currentDiff = nil
-		m.loadingDiff = true
-		var cmds []tea.Cmd
-		cmds = append(cmds, m.loadDiff(m.currentCommit().Hash))
-		if m.playing {
-			cmds = append(cmds, playTick(m.speed))
-		}
-		return m, tea.Batch(cmds...)
+		return m, m.loadFrame()
 	}
 	// Reached end — stop playback
 	m.playing = false
@@ -401,9 +1056,7 @@ func (m Model) stepForward() (Model, tea.Cmd) {
 func (m Model) stepBackward() (Model, tea.Cmd) {
 	if m.cursor > 0 {
 		m.cursor--
-		m.currentDiff = nil
-		m.loadingDiff = true
-		return m, m.loadDiff(m.currentCommit().Hash)
+		return m, m.loadFrame()
 	}
 	return m, nil
 }
@@ -413,9 +1066,29 @@ func (m *Model) stopPlaying() {
 	m.state = StateReady
 }
 
-func (m *Model) recalcLayout() {
-	m.leftWidth = m.width * 38 / 100
-	m.rightWidth = m.width - m.leftWidth - 3
+// rebuildTree rebuilds the left pane tree from the current frame's data.
+func (m *Model) rebuildTree() {
+	m.fileTree = nil
+	var changes []git.FileChange
+	if m.currentDiff != nil {
+		changes = m.currentDiff.Changes
+	}
+	switch m.leftView {
+	case ViewCommitList:
+		return
+	case ViewRepoTree:
+		if m.repoFiles == nil {
+			return
+		}
+		m.fileTree = buildTree(m.repoFiles, changes)
+	default:
+		if m.currentDiff == nil {
+			return
+		}
+		m.fileTree = buildTree(nil, changes)
+		compactTree(m.fileTree)
+	}
+	sortTree(m.fileTree, m.treeSort)
 }
 
 // ── View ──────────────────────────────────────────────────────────────────────
@@ -432,7 +1105,7 @@ func (m Model) View() string {
 	}
 
 	if m.err != nil {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#f7768e")).Bold(true).
+		return lipgloss.NewStyle().Foreground(ColorDeleted).Bold(true).
 			Render(fmt.Sprintf("\n  Error: %v\n\n  Press q to quit.", m.err))
 	}
 
@@ -441,27 +1114,24 @@ func (m Model) View() string {
 			Render("\n  No commits found in this branch.\n\n  Press q to quit.")
 	}
 
-	m.recalcLayout()
+	if m.state == StateHelp {
+		return renderHelp(&m)
+	}
 
-	header := renderHeader(&m)
-	legend := renderLegend(&m)
+	m.recalcLayout()
 
 	// ── Main body ─────────────────────────────────────────────────────────────
 	var body string
 	if m.state == StateSearching {
-		body = PaneStyle.Width(m.width - 4).Height(m.height - 9).
-			Render(renderSearchResults(&m))
+		body = paneBox(PaneStyle, m.width-4, m.bodyHeight(), renderSearchResults(&m))
+	} else if m.state == StatePickingFunc {
+		body = paneBox(PaneStyle, m.width-4, m.bodyHeight(), renderFuncPicker(&m))
+	} else if m.state == StateBookmarks {
+		body = paneBox(PaneStyle, m.width-4, m.bodyHeight(), renderBookmarkList(&m))
+	} else if m.state == StatePickingAuthors {
+		body = paneBox(PaneStyle, m.width-4, m.bodyHeight(), renderAuthorPicker(&m))
 	} else {
-		leftStyle := PaneStyle
-		rightStyle := PaneStyle
-		if m.activePane == PaneFiles {
-			leftStyle = ActivePaneStyle
-		} else {
-			rightStyle = ActivePaneStyle
-		}
-		left := leftStyle.Width(m.leftWidth).Height(m.height - 9).Render(renderFileTree(&m))
-		right := rightStyle.Width(m.rightWidth).Height(m.height - 9).Render(renderDetail(&m))
-		body = lipgloss.JoinHorizontal(lipgloss.Top, left, " ", right)
+		body = renderBody(&m)
 	}
 
 	// ── Timeline ──────────────────────────────────────────────────────────────
@@ -472,13 +1142,29 @@ func (m Model) View() string {
 	switch m.state {
 	case StateSearching:
 		statusBar = renderSearchBar(&m)
-	case StateFiltering:
-		statusBar = renderFilterBar(&m)
+	case StatePickingAuthors:
+		statusBar = renderAuthorPickerBar(&m)
+	case StatePickingFunc:
+		statusBar = renderFuncBar(&m)
+	case StateNoting:
+		statusBar = renderNoteBar(&m)
+	case StateBookmarks:
+		statusBar = renderBookmarkBar(&m)
+	case StateCommand:
+		statusBar = renderCommandBar(&m)
 	default:
 		statusBar = renderStatusBar(&m)
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, legend, body, timeline, statusBar)
+	var rows []string
+	if m.headerRows() > 0 {
+		rows = append(rows, renderHeader(&m), renderLegend(&m))
+	}
+	rows = append(rows, body, timeline)
+	if m.statusRows() > 0 {
+		rows = append(rows, statusBar)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 func (m Model) renderLoading() string {
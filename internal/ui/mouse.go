@@ -0,0 +1,164 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+// handleMouse handles clicks, drags, hovering and the wheel. Hit-testing uses
+// the same geometry helpers the views render with.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateReady && m.state != StatePlaying {
+		return m, nil
+	}
+
+	onBar := msg.Y == m.timelineRow()
+	idx, inBar := m.timelineIndexAt(msg.X)
+
+	// ── Scrubbing ────────────────────────────────────────────────────────────
+	switch {
+	case m.scrubbing && msg.Action == tea.MouseActionMotion:
+		// Frames load once the button is released.
+		m.cursor = m.clampedTimelineIndex(msg.X)
+		m.resetFrame()
+		return m, nil
+	case m.scrubbing && msg.Action == tea.MouseActionRelease:
+		m.scrubbing = false
+		return m, m.loadFrame()
+	}
+
+	// ── Jumplist ─────────────────────────────────────────────────────────────
+	if msg.Action == tea.MouseActionPress {
+		switch msg.Button {
+		case tea.MouseButtonBackward:
+			return m, m.jumpBack()
+		case tea.MouseButtonForward:
+			return m, m.jumpForward()
+		}
+	}
+
+	// ── Hover tooltip ────────────────────────────────────────────────────────
+	m.hoverIdx = -1
+	if onBar && inBar {
+		m.hoverIdx = idx
+	}
+	if msg.Action == tea.MouseActionMotion {
+		return m, nil // only the scrubber follows the pointer
+	}
+
+	if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft && onBar && inBar {
+		m.stopPlaying()
+		m.scrubbing = true
+		m.hoverIdx = -1
+		m.recordJump()
+		m.cursor = idx
+		m.resetFrame()
+		return m, nil
+	}
+
+	// ── Panes ────────────────────────────────────────────────────────────────
+	if m.shown == layoutTabbed && !m.zen && msg.Y == m.headerRows() &&
+		msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+		if pane, ok := tabAt(&m, msg.X); ok {
+			m.activePane = pane
+		}
+		return m, nil
+	}
+	pane, inPane := m.paneAt(msg.X, msg.Y)
+	if !inPane {
+		return m, nil
+	}
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelDown:
+		if pane == PaneFiles && m.leftView == ViewCommitList {
+			// The list follows the cursor, so the wheel moves it like J/K.
+			mv := scrollLineDown
+			if msg.Button == tea.MouseButtonWheelUp {
+				mv = scrollLineUp
+			}
+			return m, m.scrollCommitList(mv)
+		} else if pane == PaneFiles {
+			m.leftVP, _ = m.leftVP.Update(msg)
+		} else {
+			m.rightVP, _ = m.rightVP.Update(msg)
+		}
+	case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		m.activePane = pane
+		if pane == PaneFiles && m.leftView == ViewCommitList {
+			line := msg.Y - m.paneContentTop()
+			if line >= 0 && line < m.leftVP.Height {
+				m.stopPlaying()
+				return m, m.jumpTo(commitListStart(&m) + line)
+			}
+		} else if pane == PaneFiles {
+			m.clickTreeRow(msg.Y - m.paneContentTop())
+		}
+	}
+	return m, nil
+}
+
+// clickTreeRow selects the tree row at the given viewport line. Clicking the
+// already selected directory toggles it.
+func (m *Model) clickTreeRow(line int) {
+	if line < 0 || line >= m.leftVP.Height {
+		return
+	}
+	row := m.leftVP.YOffset + line
+	rows := m.treeRows()
+	if row >= len(rows) {
+		return
+	}
+	if row == m.treeSel && rows[row].node.dir {
+		n := rows[row].node
+		m.treeExpanded[n.path] = !isExpanded(n, m.treeExpanded)
+		return
+	}
+	m.moveTreeSel(row - m.treeSel)
+}
+
+// timelineRow is the screen row of the scrubber bar, right below the panes.
+func (m *Model) timelineRow() int {
+	return m.headerRows() + m.bodyHeight() + 2
+}
+
+// timelineIndexAt maps a screen column on the scrubber bar to the earliest
+// commit in that column, or in the closest column that has one.
+func (m *Model) timelineIndexAt(x int) (int, bool) {
+	x0, width := timelineBarSpan(m)
+	if x < x0 || x >= x0+width || len(m.activeCommits()) == 0 {
+		return 0, false
+	}
+	return m.clampedTimelineIndex(x), true
+}
+
+// clampedTimelineIndex maps any screen column to a commit index, pinning
+// columns left or right of the bar to its first or last column.
+func (m *Model) clampedTimelineIndex(x int) int {
+	x0, width := timelineBarSpan(m)
+	col := max(0, min(x-x0, width-1))
+	idx, _ := timelineStrip(m, width).nearest(col)
+	return idx
+}
+
+// paneAt reports which pane, if any, contains the screen cell.
+func (m *Model) paneAt(x, y int) (ActivePane, bool) {
+	in := func(left, top, w, h int) bool {
+		return x >= left && x < left+w+2 && y >= top && y < top+h+2
+	}
+	switch {
+	case m.paneShown(PaneFiles) && in(0, m.leftTop, m.leftWidth, m.leftHeight):
+		return PaneFiles, true
+	case m.paneShown(PaneDetail) && in(m.rightLeft, m.rightTop, m.rightWidth, m.rightHeight):
+		return PaneDetail, true
+	}
+	return PaneFiles, false
+}
+
+// hoverCommit returns the commit under the pointer on the scrubber, if any.
+func (m *Model) hoverCommit() (git.Commit, bool) {
+	ac := m.activeCommits()
+	if m.hoverIdx < 0 || m.hoverIdx >= len(ac) {
+		return git.Commit{}, false
+	}
+	return ac[m.hoverIdx], true
+}
@@ -0,0 +1,297 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/meetsoni15/gitcinema/internal/git"
+	"github.com/meetsoni15/gitcinema/internal/query"
+)
+
+// paletteCommands are the commands the ":" palette knows, for completion.
+// A bare number jumps to that frame and "NN%" to that point in the history.
+var paletteCommands = []string{"goto", "date", "filter", "save", "unsave", "speed", "branch"}
+
+type gotoMsg struct {
+	rev  string
+	hash string
+	err  error
+}
+
+func (m Model) resolveRev(rev string) tea.Cmd {
+	return func() tea.Msg {
+		hash, err := git.ResolveCommit(m.root, rev)
+		return gotoMsg{rev: rev, hash: hash, err: err}
+	}
+}
+
+type branchMsg struct {
+	branch string
+	err    error
+}
+
+// checkBranch makes sure a branch exists before the history is reloaded
+// from it.
+func (m Model) checkBranch(branch string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := git.ResolveCommit(m.root, branch)
+		return branchMsg{branch: branch, err: err}
+	}
+}
+
+// openPalette starts a new command in the palette.
+func (m *Model) openPalette() {
+	m.cmdInput = ""
+	m.cmdHistIdx = len(m.cmdHistory)
+	m.completions = nil
+	m.state = StateCommand
+}
+
+func (m Model) handleCommandKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.state = StateReady
+		m.cmdInput = ""
+	case key.Matches(msg, m.keys.Accept):
+		m.state = StateReady
+		line := strings.TrimSpace(m.cmdInput)
+		m.cmdInput = ""
+		if line == "" {
+			return m, nil
+		}
+		if n := len(m.cmdHistory); n == 0 || m.cmdHistory[n-1] != line {
+			m.cmdHistory = append(m.cmdHistory, line)
+		}
+		return m, m.runCommand(line)
+	case key.Matches(msg, m.keys.InputUp):
+		if m.cmdHistIdx > 0 {
+			m.cmdHistIdx--
+			m.cmdInput = m.cmdHistory[m.cmdHistIdx]
+		}
+	case key.Matches(msg, m.keys.InputDown):
+		if m.cmdHistIdx < len(m.cmdHistory) {
+			m.cmdHistIdx++
+			m.cmdInput = ""
+			if m.cmdHistIdx < len(m.cmdHistory) {
+				m.cmdInput = m.cmdHistory[m.cmdHistIdx]
+			}
+		}
+	case key.Matches(msg, m.keys.Complete):
+		m.complete()
+	case key.Matches(msg, m.keys.DeleteBack):
+		if r := []rune(m.cmdInput); len(r) > 0 {
+			m.cmdInput = string(r[:len(r)-1])
+		}
+		m.completions = nil
+	default:
+		if len(msg.Runes) > 0 {
+			m.cmdInput += string(msg.Runes)
+			m.completions = nil
+		}
+	}
+	return m, nil
+}
+
+// runCommand runs one palette command line.
+func (m *Model) runCommand(line string) tea.Cmd {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+
+	if p, ok := strings.CutSuffix(name, "%"); ok && arg == "" {
+		pct, err := strconv.ParseFloat(p, 64)
+		if err != nil || pct < 0 || pct > 100 {
+			m.notice = "percentage must be between 0% and 100%"
+			return nil
+		}
+		n := len(m.activeCommits())
+		return m.bigJump(int(math.Round(pct / 100 * float64(n-1))))
+	}
+	if n, err := strconv.Atoi(name); err == nil && arg == "" {
+		return m.bigJump(n - 1)
+	}
+
+	switch name {
+	case "goto", "g":
+		if arg == "" {
+			m.notice = "usage: goto <hash|tag|ref>"
+			return nil
+		}
+		for i, c := range m.activeCommits() {
+			if strings.HasPrefix(c.Hash, arg) && len(arg) >= 4 {
+				return m.bigJump(i)
+			}
+		}
+		return m.resolveRev(arg)
+
+	case "date", "d":
+		t, err := query.ParseDate(arg, time.Now())
+		if err != nil {
+			m.notice = "date: " + err.Error()
+			return nil
+		}
+		for i, c := range m.activeCommits() {
+			if !c.Timestamp.Before(t) {
+				return m.bigJump(i)
+			}
+		}
+		m.notice = "no commits on or after " + arg
+		return nil
+
+	case "filter", "f":
+		return m.filterByQuery(arg)
+
+	case "save":
+		m.saveFilter(arg)
+		return nil
+
+	case "unsave":
+		m.unsaveFilter(arg)
+		return nil
+
+	case "speed", "s":
+		v, err := strconv.ParseFloat(strings.TrimSuffix(arg, "x"), 64)
+		if err != nil || v <= 0 {
+			m.notice = "usage: speed <multiplier>, e.g. speed 8"
+			return nil
+		}
+		m.SetSpeed(v)
+		return nil
+
+	case "branch", "b":
+		if arg == "" {
+			m.notice = "usage: branch <name>"
+			return nil
+		}
+		return m.checkBranch(arg)
+	}
+	m.notice = fmt.Sprintf("unknown command %q", name)
+	return nil
+}
+
+// gotoResolved jumps to a revision resolved by git.
+func (m *Model) gotoResolved(msg gotoMsg) tea.Cmd {
+	if msg.err != nil {
+		m.notice = msg.err.Error()
+		return nil
+	}
+	if i, ok := m.indexOf(msg.hash); ok {
+		return m.bigJump(i)
+	}
+	m.notice = msg.rev + " is not in the loaded history"
+	return nil
+}
+
+// switchBranch reloads the history from another branch. Filters are reset;
+// bookmarks and marks stay on their commits.
+func (m *Model) switchBranch(msg branchMsg) tea.Cmd {
+	if msg.err != nil {
+		m.notice = msg.err.Error()
+		return nil
+	}
+	m.branch = msg.branch
+	m.cancelFuncs()
+	m.state = StateLoading
+	m.cursor = 0
+	m.authorMarks, m.funcFilter, m.filteredCommits = nil, nil, nil
+	m.queryFilter, m.filterPending = nil, false
+	m.summaries, m.cut, m.summariesLoading = nil, nil, false
+	cmds := []tea.Cmd{m.loadHistory(), spinnerTick()}
+	if m.cutOn || (m.pauseOn && m.pauseRules.needSummaries()) {
+		cmds = append(cmds, m.ensureSummaries())
+	}
+	return tea.Batch(cmds...)
+}
+
+// complete extends the input with Tab: command names first, then the
+// argument from the tags, branches or saved filters that fit the command. A
+// filter query completes its last word.
+func (m *Model) complete() {
+	name, arg, hasArg := strings.Cut(m.cmdInput, " ")
+	var cands []string
+	prefix := name
+	if !hasArg {
+		cands = paletteCommands
+	} else {
+		prefix = arg
+		switch name {
+		case "goto", "g":
+			for _, names := range m.tags {
+				cands = append(cands, names...)
+			}
+			cands = append(cands, m.branches...)
+		case "branch", "b":
+			cands = m.branches
+		case "filter", "f":
+			if i := strings.LastIndex(arg, " "); i >= 0 {
+				name, prefix = name+" "+arg[:i], arg[i+1:]
+			}
+			cands = m.queryCandidates(prefix)
+		case "unsave":
+			if m.savedFilters != nil {
+				for n := range m.savedFilters.All() {
+					cands = append(cands, n)
+				}
+			}
+		}
+	}
+
+	var matches []string
+	unquoted := strings.ToLower(strings.ReplaceAll(prefix, `"`, ""))
+	for _, c := range cands {
+		if strings.HasPrefix(strings.ToLower(strings.ReplaceAll(c, `"`, "")), unquoted) {
+			matches = append(matches, c)
+		}
+	}
+	sort.Strings(matches)
+	m.completions = matches
+	if len(matches) == 0 {
+		return
+	}
+
+	fill := matches[0]
+	if len(matches) > 1 {
+		fill = commonPrefix(matches)
+		if len(fill) < len(prefix) {
+			return
+		}
+	}
+	if !hasArg {
+		m.cmdInput = fill
+		if len(matches) == 1 {
+			m.cmdInput += " "
+		}
+		return
+	}
+	m.cmdInput = name + " " + fill
+}
+
+func commonPrefix(ss []string) string {
+	p := ss[0]
+	for _, s := range ss[1:] {
+		for !strings.HasPrefix(s, p) {
+			p = p[:len(p)-1]
+		}
+	}
+	return p
+}
+
+// renderCommandBar renders the palette input, with the candidates of the
+// last completion after it.
+func renderCommandBar(m *Model) string {
+	prompt := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).Render(":")
+	input := lipgloss.NewStyle().Foreground(ColorText).Render(m.cmdInput)
+	cursor := lipgloss.NewStyle().Foreground(ColorAccent).Render("█")
+	hint := HelpStyle.Render("  goto · date · NN% · filter · save · speed · branch   ") +
+		helpLine(shortHelp("complete", m.keys.Complete), shortHelp("history", m.keys.InputUp, m.keys.InputDown))
+	if len(m.completions) > 1 {
+		hint = HelpStyle.Render("  " + truncate(strings.Join(m.completions, "  "), max(m.width-lipgloss.Width(m.cmdInput)-12, 3)))
+	}
+	return StatusBarStyle.Width(m.width).Render("  " + prompt + input + cursor + hint)
+}
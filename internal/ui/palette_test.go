@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+func TestSwitchBranchDropsSummariesOfTheOldBranch(t *testing.T) {
+	m := New(".", "main", 0)
+	m.state = StateReady
+	m.commits = []git.Commit{{Hash: "a"}}
+	m.registry = git.BuildRegistry(m.commits, git.Identities{})
+	m.SetCut(DefaultCutRules(), true)
+	if !m.summariesLoading {
+		t.Fatal("the cut did not start loading the summaries")
+	}
+
+	m.switchBranch(branchMsg{branch: "dev"})
+	if !m.summariesLoading {
+		t.Fatal("switching branches did not reload the summaries")
+	}
+
+	var tm tea.Model = m
+	old := map[string]git.ChangeSummary{"a": {Additions: 1}}
+	tm, _ = tm.Update(summariesMsg{branch: "main", summaries: old})
+	if m = tm.(Model); m.summaries != nil || !m.summariesLoading {
+		t.Fatalf("summaries of main applied on dev: %v, loading %v", m.summaries, m.summariesLoading)
+	}
+
+	cur := map[string]git.ChangeSummary{"b": {Additions: 2}}
+	tm, _ = tm.Update(summariesMsg{branch: "dev", summaries: cur})
+	if m = tm.(Model); m.summaries["b"].Additions != 2 || m.summariesLoading {
+		t.Fatalf("summaries of dev not applied: %v, loading %v", m.summaries, m.summariesLoading)
+	}
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// Pane titles stay fixed while a viewport scrolls the content below them.
+// Content is re-rendered into the viewports after every update that can
+// change it, so scrolling always works against what is on screen.
+
+// paneContentTop is the screen row of the first viewport line in the file
+// pane: below its border, the title and its divider.
+func (m *Model) paneContentTop() int {
+	return m.leftTop + 3
+}
+
+// syncViewports sizes both pane viewports and refreshes their content.
+func (m *Model) syncViewports() {
+	m.syncLeftViewport()
+
+	m.rightVP.Width = max(m.rightWidth-2, 1)
+	m.rightVP.Height = max(m.rightHeight-2, 1) // title, divider
+	m.rightVP.SetContent(rightPaneContent(m))
+}
+
+func (m *Model) syncLeftViewport() {
+	m.leftVP.Width = max(m.leftWidth-2, 1)
+	m.leftVP.Height = max(m.leftHeight-3, 1) // title, divider, footer
+	m.leftVP.SetContent(fileTreeContent(m))
+}
+
+// scrollMove is one scroll step of the focused pane.
+type scrollMove int
+
+const (
+	scrollLineDown scrollMove = iota
+	scrollLineUp
+	scrollPageDown
+	scrollPageUp
+	scrollHalfDown
+	scrollHalfUp
+	scrollTop
+	scrollBottom
+)
+
+// scrollPane scrolls the focused pane. The file pane moves its selection, and
+// the viewport follows it.
+func (m *Model) scrollPane(mv scrollMove) {
+	if m.activePane == PaneFiles {
+		page := max(m.leftVP.Height, 1)
+		switch mv {
+		case scrollLineDown:
+			m.moveTreeSel(1)
+		case scrollLineUp:
+			m.moveTreeSel(-1)
+		case scrollPageDown:
+			m.moveTreeSel(page)
+		case scrollPageUp:
+			m.moveTreeSel(-page)
+		case scrollHalfDown:
+			m.moveTreeSel(page / 2)
+		case scrollHalfUp:
+			m.moveTreeSel(-page / 2)
+		case scrollTop:
+			m.moveTreeSel(-m.treeSel)
+		case scrollBottom:
+			m.moveTreeSel(len(m.treeRows()))
+		}
+		return
+	}
+
+	switch mv {
+	case scrollLineDown:
+		m.rightVP.ScrollDown(1)
+	case scrollLineUp:
+		m.rightVP.ScrollUp(1)
+	case scrollPageDown:
+		m.rightVP.PageDown()
+	case scrollPageUp:
+		m.rightVP.PageUp()
+	case scrollHalfDown:
+		m.rightVP.HalfPageDown()
+	case scrollHalfUp:
+		m.rightVP.HalfPageUp()
+	case scrollTop:
+		m.rightVP.GotoTop()
+	case scrollBottom:
+		m.rightVP.GotoBottom()
+	}
+}
+
+// paneTitle renders a pane title with a scroll indicator and a divider below.
+func paneTitle(title, extra string, vp viewport.Model, dividerWidth int) string {
+	return TitleStyle.Render(title) + extra + scrollIndicator(vp) + "\n" +
+		strings.Repeat("─", max(dividerWidth, 0))
+}
+
+// scrollIndicator shows how far a viewport is scrolled when its content
+// does not fit, e.g. "↑ 40% ↓".
+func scrollIndicator(vp viewport.Model) string {
+	if vp.TotalLineCount() <= vp.Height {
+		return ""
+	}
+	up, down := " ", " "
+	if !vp.AtTop() {
+		up = "↑"
+	}
+	if !vp.AtBottom() {
+		down = "↓"
+	}
+	return HelpStyle.Render(fmt.Sprintf("  %s %.0f%% %s", up, vp.ScrollPercent()*100, down))
+}
+
+// treeRows returns the visible rows of the left pane tree.
+func (m *Model) treeRows() []treeRow {
+	if m.fileTree == nil {
+		return nil
+	}
+	return m.fileTree.rows(m.treeExpanded)
+}
+
+// selectedNode returns the node under the left pane selection, if any.
+func (m *Model) selectedNode() *treeNode {
+	rows := m.treeRows()
+	if len(rows) == 0 {
+		return nil
+	}
+	if m.treeSel >= len(rows) {
+		m.treeSel = len(rows) - 1
+	}
+	return rows[m.treeSel].node
+}
+
+// moveTreeSel moves the left pane selection by delta rows, scrolling to keep
+// it in view.
+func (m *Model) moveTreeSel(delta int) {
+	rows := m.treeRows()
+	if len(rows) == 0 {
+		return
+	}
+	m.treeSel += delta
+	if m.treeSel < 0 {
+		m.treeSel = 0
+	}
+	if m.treeSel >= len(rows) {
+		m.treeSel = len(rows) - 1
+	}
+	m.syncLeftViewport()
+	if m.treeSel < m.leftVP.YOffset {
+		m.leftVP.SetYOffset(m.treeSel)
+	}
+	if m.treeSel >= m.leftVP.YOffset+m.leftVP.Height {
+		m.leftVP.SetYOffset(m.treeSel - m.leftVP.Height + 1)
+	}
+}
+
+// collapseSelected collapses the selected directory, or the directory
+// containing the selected file, moving the selection onto it.
+func (m *Model) collapseSelected() {
+	n := m.selectedNode()
+	if n == nil {
+		return
+	}
+	target := n.path
+	if !n.dir || !isExpanded(n, m.treeExpanded) {
+		target = parentPath(n.path)
+	}
+	if target == "" {
+		return
+	}
+	m.treeExpanded[target] = false
+	for i, r := range m.treeRows() {
+		if r.node.path == target {
+			m.moveTreeSel(i - m.treeSel)
+			break
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/git"
+	"github.com/meetsoni15/gitcinema/internal/query"
+)
+
+// PauseRules decide which frames stop playback when pause-on-event is on.
+type PauseRules struct {
+	Paths      []string         // commits touching these directories, files or globs
+	NewAuthors bool             // an author's first commit
+	Tags       bool             // tagged commits
+	MinLines   int              // commits changing at least this many lines, 0 = off
+	Messages   []*regexp.Regexp // subjects matching any of these
+}
+
+// DefaultPauseRules pause on tags and on newcomers.
+func DefaultPauseRules() PauseRules {
+	return PauseRules{NewAuthors: true, Tags: true}
+}
+
+// SetPauses sets the pause-on-event rules and whether they start enabled.
+func (m *Model) SetPauses(rules PauseRules, on bool) {
+	m.pauseRules = rules
+	m.pauseOn = on
+	if on && rules.needSummaries() {
+		m.summariesLoading = true // Init starts the load
+	}
+}
+
+// togglePauses switches pause-on-event, loading commit sizes if a rule
+// needs them.
+func (m *Model) togglePauses() tea.Cmd {
+	m.pauseOn = !m.pauseOn
+	if m.pauseOn && m.pauseRules.needSummaries() {
+		return m.ensureSummaries()
+	}
+	return nil
+}
+
+func (r PauseRules) needSummaries() bool {
+	return len(r.Paths) > 0 || r.MinLines > 0
+}
+
+// pauseReason returns the rule frame i triggers, or "" when playback should
+// carry on. Rules needing change sizes are skipped until those are loaded.
+func (m *Model) pauseReason(i int) string {
+	if !m.pauseOn {
+		return ""
+	}
+	c := m.activeCommits()[i]
+	r := m.pauseRules
+	if r.Tags && len(m.tags[c.Hash]) > 0 {
+		return "tag " + strings.Join(m.tags[c.Hash], ", ")
+	}
+	if r.NewAuthors && m.authorFirst[c.Email] == c.Hash {
+		return "new author " + c.Author
+	}
+	for _, re := range r.Messages {
+		if re.MatchString(c.Subject) {
+			return "message /" + re.String() + "/"
+		}
+	}
+	s, ok := m.summaries[c.Hash]
+	if !ok {
+		return ""
+	}
+	if r.MinLines > 0 && s.Lines() >= r.MinLines {
+		return fmt.Sprintf("%d lines changed", s.Lines())
+	}
+	for _, f := range s.Files {
+		if p, ok := query.MatchGlob(r.Paths, f); ok {
+			return "touched " + p
+		}
+	}
+	return ""
+}
+
+// firstCommits returns the hash of each author's first commit, by email.
+func firstCommits(commits []git.Commit) map[string]string {
+	first := map[string]string{}
+	for _, c := range commits {
+		if _, ok := first[c.Email]; !ok {
+			first[c.Email] = c.Hash
+		}
+	}
+	return first
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxFrameSpeed is the fastest frame rate, as a speed multiplier. Beyond it,
+// playback keeps this frame rate and skips commits instead.
+const maxFrameSpeed = 4.0
+
+// framesPerCommitPresets are how many frames each commit is held for. Values
+// below one play several commits per frame, for very long histories.
+var framesPerCommitPresets = []float64{8, 4, 2, 1, 1.0 / 2, 1.0 / 10, 1.0 / 100, 1.0 / 1000}
+var defaultFPCIdx = 3 // one frame per commit
+
+// compressionPresets are how much history one second of real-time playback
+// covers.
+var compressionPresets = []time.Duration{
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	365 * 24 * time.Hour,
+}
+var defaultCompressionIdx = 2 // 1 day per second
+
+// Real-time frames are clamped so bursts of commits stay visible and long
+// quiet stretches do not stall playback.
+const (
+	minRealtimeFrame = 80 * time.Millisecond
+	maxRealtimeFrame = 4 * time.Second
+)
+
+// clockInterval is how often the date clock redraws during real-time playback.
+const clockInterval = 100 * time.Millisecond
+
+// playTickMsg advances playback. Ticks from an earlier schedule (gen differs
+// from Model.playGen) are stale and ignored.
+type playTickMsg struct{ gen int }
+
+// clockTickMsg redraws the date clock between real-time frames.
+type clockTickMsg struct{ gen int }
+
+// SetSpeed sets the playback speed multiplier, clamped to the supported range.
+func (m *Model) SetSpeed(speed float64) {
+	m.speed = max(minSpeed, min(speed, maxSpeed))
+}
+
+// advance plays the next frame, moving the cursor by as many commits as the
+// speed and frames-per-commit setting call for. At the end of the play range
+// it loops back to the start or stops, and it stops early on frames that
+// trigger a pause rule.
+func (m Model) advance() (Model, tea.Cmd) {
+	lo, hi := m.playRange()
+	dir, start, end := 1, lo, hi
+	if m.reverse {
+		dir, start, end = -1, hi, lo
+	}
+	if m.cursor == end || (dir > 0 && m.cursor > hi) || (dir < 0 && m.cursor < lo) {
+		if !m.loop {
+			m.stopPlaying()
+			return m, nil
+		}
+		m.cursor = start
+		m.stepAcc = 0
+		return m, tea.Batch(m.loadFrame(), m.schedulePlay())
+	}
+
+	n := m.commitsThisFrame()
+	if n == 0 {
+		// Holding the frame for another tick.
+		return m, m.schedulePlay()
+	}
+	target := max(lo, min(m.cursor+dir*n, hi))
+	if !m.cutRules.FastForward {
+		for target != end && m.isCut(target) {
+			target += dir
+		}
+	}
+
+	// Stop on the first frame along the way that triggers a pause rule.
+	if m.pauseOn {
+		for i := m.cursor + dir; i != target+dir; i += dir {
+			if m.isCut(i) {
+				continue
+			}
+			if r := m.pauseReason(i); r != "" {
+				m.cursor = i
+				cmd := m.loadFrame()
+				m.stopPlaying()
+				m.pausedBy = r
+				return m, cmd
+			}
+		}
+	}
+
+	m.cursor = target
+	return m, tea.Batch(m.loadFrame(), m.schedulePlay())
+}
+
+// commitsThisFrame returns how many commits the next frame moves. The
+// fractional part carries over to later frames, so 6x alternates between one
+// and two commits per frame instead of rounding to either.
+func (m *Model) commitsThisFrame() int {
+	rate := 1.0
+	if !m.realtime {
+		rate = max(m.speed/maxFrameSpeed, 1) / framesPerCommitPresets[m.fpcIdx]
+	}
+	m.stepAcc += rate
+	n := int(m.stepAcc)
+	m.stepAcc -= float64(n)
+	return n
+}
+
+// schedulePlay starts the timer for the current frame, replacing any pending
+// one.
+func (m *Model) schedulePlay() tea.Cmd {
+	m.playGen++
+	m.frameStart = time.Now()
+	m.frameDur = m.frameDuration()
+
+	gen := m.playGen
+	cmds := []tea.Cmd{tea.Tick(m.frameDur, func(time.Time) tea.Msg { return playTickMsg{gen: gen} })}
+	if m.realtime {
+		cmds = append(cmds, clockTick(gen))
+	}
+	return tea.Batch(cmds...)
+}
+
+func clockTick(gen int) tea.Cmd {
+	return tea.Tick(clockInterval, func(time.Time) tea.Msg { return clockTickMsg{gen: gen} })
+}
+
+// frameDuration returns how long the current frame stays on screen. In
+// real-time mode it is proportional to the time until the next commit; frames
+// the director's cut fast-forwards through flash by.
+func (m *Model) frameDuration() time.Duration {
+	if m.cutRules.FastForward && m.isCut(m.cursor) {
+		return cutFrame
+	}
+	if !m.realtime {
+		return time.Duration(float64(m.interval) / min(m.speed, maxFrameSpeed))
+	}
+	gap, ok := m.nextGap()
+	if !ok {
+		return minRealtimeFrame
+	}
+	perSec := compressionPresets[m.compressionIdx]
+	dur := time.Duration(float64(abs(gap)) / float64(perSec) * float64(time.Second) / m.speed)
+	return max(minRealtimeFrame, min(dur, maxRealtimeFrame))
+}
+
+// nextGap returns the author time between the current frame and the one
+// playback moves to next, negative when playing in reverse.
+func (m *Model) nextGap() (time.Duration, bool) {
+	ac := m.activeCommits()
+	next := m.cursor + 1
+	if m.reverse {
+		next = m.cursor - 1
+	}
+	if m.cursor < 0 || next < 0 || next >= len(ac) {
+		return 0, false
+	}
+	gap := ac[next].Timestamp.Sub(ac[m.cursor].Timestamp)
+	if m.reverse {
+		return min(gap, 0), true
+	}
+	return max(gap, 0), true
+}
+
+// clock returns the simulated date of real-time playback: it moves from the
+// current commit towards the next one as the frame plays out.
+func (m *Model) clock(now time.Time) time.Time {
+	t := m.currentCommit().Timestamp
+	gap, ok := m.nextGap()
+	if !m.playing || !ok || m.frameDur <= 0 {
+		return t
+	}
+	frac := float64(now.Sub(m.frameStart)) / float64(m.frameDur)
+	frac = max(0, min(frac, 1))
+	return t.Add(time.Duration(frac * float64(gap)))
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// fpcLabel renders the frames-per-commit setting, e.g. "4f/c" or "1/100f/c".
+func fpcLabel(fpc float64) string {
+	if fpc >= 1 {
+		return fmt.Sprintf("%gf/c", fpc)
+	}
+	return fmt.Sprintf("1/%gf/c", 1/fpc)
+}
+
+// compressionLabel renders the real-time compression, e.g. "1d/s".
+func compressionLabel(d time.Duration) string {
+	switch {
+	case d >= 365*24*time.Hour:
+		return fmt.Sprintf("%dy/s", d/(365*24*time.Hour))
+	case d >= 30*24*time.Hour:
+		return fmt.Sprintf("%dmo/s", d/(30*24*time.Hour))
+	case d >= 7*24*time.Hour:
+		return fmt.Sprintf("%dw/s", d/(7*24*time.Hour))
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd/s", d/(24*time.Hour))
+	}
+	return fmt.Sprintf("%dh/s", d/time.Hour)
+}
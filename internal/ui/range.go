@@ -0,0 +1,82 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Marks are kept as commit hashes rather than indices so they stay on the
+// same commits when a filter changes the active history. A mark whose commit
+// is filtered out is ignored until the filter is cleared.
+
+// indexOf returns the index of the commit with the given hash in the active
+// history.
+func (m *Model) indexOf(hash string) (int, bool) {
+	if hash == "" {
+		return 0, false
+	}
+	for i, c := range m.activeCommits() {
+		if c.Hash == hash {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// playRange returns the first and last frame playback runs between: the in
+// and out marks, or the whole history for a missing mark.
+func (m *Model) playRange() (lo, hi int) {
+	lo, hi = 0, len(m.activeCommits())-1
+	if i, ok := m.indexOf(m.markIn); ok {
+		lo = i
+	}
+	if i, ok := m.indexOf(m.markOut); ok {
+		hi = i
+	}
+	return lo, hi
+}
+
+// hasRange reports whether an in or out mark narrows playback.
+func (m *Model) hasRange() bool {
+	_, in := m.indexOf(m.markIn)
+	_, out := m.indexOf(m.markOut)
+	return in || out
+}
+
+// setMark puts the in or out mark on the current frame, swapping the two if
+// they would end up in the wrong order.
+func (m *Model) setMark(in bool) {
+	hash := m.currentCommit().Hash
+	if in {
+		m.markIn = hash
+	} else {
+		m.markOut = hash
+	}
+	i, okIn := m.indexOf(m.markIn)
+	o, okOut := m.indexOf(m.markOut)
+	if okIn && okOut && i > o {
+		m.markIn, m.markOut = m.markOut, m.markIn
+	}
+}
+
+// togglePlay starts or pauses playback. Starting outside the marked range, or
+// at its end, begins from the start of the range instead.
+func (m *Model) togglePlay() tea.Cmd {
+	m.playing = !m.playing
+	if !m.playing {
+		m.state = StateReady
+		return nil
+	}
+	m.state = StatePlaying
+	m.stepAcc = 0
+
+	lo, hi := m.playRange()
+	start, end := lo, hi
+	if m.reverse {
+		start, end = hi, lo
+	}
+	if m.cursor < lo || m.cursor > hi || m.cursor == end {
+		m.cursor = start
+		return tea.Batch(m.loadFrame(), m.schedulePlay())
+	}
+	return m.schedulePlay()
+}
@@ -0,0 +1,404 @@
+package ui
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+// HeatMode selects what colors the cells of the scrubber.
+type HeatMode int
+
+const (
+	HeatVolume HeatMode = iota // brightness by number of commits
+	HeatAuthor                 // color of the author with most commits
+)
+
+func (h HeatMode) String() string {
+	if h == HeatAuthor {
+		return "authors"
+	}
+	return "volume"
+}
+
+// ZoomLevel is the span of history the scrubber shows around the playhead.
+type ZoomLevel int
+
+const (
+	ZoomAll ZoomLevel = iota // the whole history
+	ZoomYear
+	ZoomMonth
+	ZoomWeek
+	ZoomDay
+)
+
+func (z ZoomLevel) String() string {
+	switch z {
+	case ZoomYear:
+		return "year"
+	case ZoomMonth:
+		return "month"
+	case ZoomWeek:
+		return "week"
+	case ZoomDay:
+		return "day"
+	}
+	return "all"
+}
+
+// span is the length of the visible window; zero for the whole history.
+func (z ZoomLevel) span() time.Duration {
+	switch z {
+	case ZoomYear:
+		return 365 * 24 * time.Hour
+	case ZoomMonth:
+		return 30 * 24 * time.Hour
+	case ZoomWeek:
+		return 7 * 24 * time.Hour
+	case ZoomDay:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// densityGlyphs render a cell's commit count relative to the busiest cell.
+var densityGlyphs = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// scrubCell summarizes the commits falling into one column of the scrubber.
+type scrubCell struct {
+	count      int
+	kept       int    // commits the director's cut does not drop
+	first      int    // index into the commits of the earliest one here, -1 if empty
+	dominant   string // email of the author with most commits here
+	tagged     bool
+	bookmarked bool
+}
+
+// scrubStrip maps a time span of history onto the columns of the scrubber,
+// so busy and quiet stretches of the project show up as the strip's shape.
+type scrubStrip struct {
+	cells      []scrubCell
+	start, end time.Time
+	max        int // commit count of the busiest cell
+}
+
+// stripMarks are the per-commit annotations the strip shows, keyed by hash.
+type stripMarks struct {
+	tags      map[string][]string
+	cut       map[string]string // frames the director's cut drops
+	bookmarks map[string]bool
+}
+
+// stripMarks collects the annotations of the active history.
+func (m *Model) stripMarks() stripMarks {
+	sm := stripMarks{tags: m.tags, cut: m.activeCut()}
+	if m.bookmarks != nil {
+		sm.bookmarks = map[string]bool{}
+		for _, b := range m.bookmarks.All() {
+			sm.bookmarks[b.Hash] = true
+		}
+	}
+	return sm
+}
+
+// buildStrip distributes commits over width columns covering [start, end].
+// Commits outside the span are ignored.
+func buildStrip(commits []git.Commit, width int, start, end time.Time, marks stripMarks) *scrubStrip {
+	if width < 1 {
+		width = 1
+	}
+	if !end.After(start) {
+		end = start.Add(time.Second)
+	}
+	s := &scrubStrip{cells: make([]scrubCell, width), start: start, end: end}
+	for i := range s.cells {
+		s.cells[i].first = -1
+	}
+
+	tally := make([]map[string]int, width)
+	for i, c := range commits {
+		col, ok := s.column(c.Timestamp)
+		if !ok {
+			continue
+		}
+		cell := &s.cells[col]
+		cell.count++
+		if marks.cut[c.Hash] == "" {
+			cell.kept++
+		}
+		if cell.first < 0 || c.Timestamp.Before(commits[cell.first].Timestamp) {
+			cell.first = i
+		}
+		if len(marks.tags[c.Hash]) > 0 {
+			cell.tagged = true
+		}
+		if marks.bookmarks[c.Hash] {
+			cell.bookmarked = true
+		}
+		if tally[col] == nil {
+			tally[col] = map[string]int{}
+		}
+		tally[col][c.Email]++
+		if n := tally[col][c.Email]; n > tally[col][cell.dominant] || cell.dominant == "" {
+			cell.dominant = c.Email
+		}
+		if cell.count > s.max {
+			s.max = cell.count
+		}
+	}
+	return s
+}
+
+// historyStrip builds the strip for the whole active history.
+func historyStrip(m *Model, width int) *scrubStrip {
+	ac := m.activeCommits()
+	if len(ac) == 0 {
+		return buildStrip(nil, width, time.Time{}, time.Time{}, stripMarks{})
+	}
+	start, end := ac[0].Timestamp, ac[0].Timestamp
+	for _, c := range ac {
+		if c.Timestamp.Before(start) {
+			start = c.Timestamp
+		}
+		if c.Timestamp.After(end) {
+			end = c.Timestamp
+		}
+	}
+	return buildStrip(ac, width, start, end, m.stripMarks())
+}
+
+// timelineStrip returns the strip the scrubber shows at the current zoom
+// level: the whole history, or a window centered on the playhead. The strip
+// is rebuilt only when something it shows changed, since it is needed on
+// every render and every pointer event over the scrubber.
+func timelineStrip(m *Model, width int) *scrubStrip {
+	key := m.stripKey(width)
+	if m.strip != nil && m.strip.strip != nil && m.strip.key == key {
+		return m.strip.strip
+	}
+	var s *scrubStrip
+	if span := m.zoom.span(); span == 0 {
+		s = historyStrip(m, width)
+	} else {
+		center := m.currentCommit().Timestamp
+		s = buildStrip(m.activeCommits(), width, center.Add(-span/2), center.Add(span/2), m.stripMarks())
+	}
+	if m.strip != nil {
+		*m.strip = stripCache{key: key, strip: s}
+	}
+	return s
+}
+
+// stripCache holds the last strip built and what it was built from.
+type stripCache struct {
+	key   stripKey
+	strip *scrubStrip
+}
+
+// stripKey is everything a strip depends on.
+type stripKey struct {
+	commits   *git.Commit // first active commit, standing for the slice
+	n         int
+	width     int
+	zoom      ZoomLevel
+	center    time.Time // playhead of a zoomed strip
+	cutOn     bool
+	cutGen    int
+	bookmarks int // revision of the bookmark store, -1 without one
+}
+
+func (m *Model) stripKey(width int) stripKey {
+	ac := m.activeCommits()
+	k := stripKey{n: len(ac), width: width, zoom: m.zoom, cutOn: m.cutOn, cutGen: m.cutGen, bookmarks: -1}
+	if len(ac) > 0 {
+		k.commits = &ac[0]
+	}
+	if m.zoom != ZoomAll {
+		k.center = m.currentCommit().Timestamp
+	}
+	if m.bookmarks != nil {
+		k.bookmarks = m.bookmarks.Rev()
+	}
+	return k
+}
+
+// column returns the cell holding time t.
+func (s *scrubStrip) column(t time.Time) (int, bool) {
+	if t.Before(s.start) || t.After(s.end) {
+		return 0, false
+	}
+	frac := float64(t.Sub(s.start)) / float64(s.end.Sub(s.start))
+	col := int(frac * float64(len(s.cells)))
+	if col >= len(s.cells) {
+		col = len(s.cells) - 1
+	}
+	return col, true
+}
+
+// nearest returns the earliest commit of the non-empty cell closest to col.
+func (s *scrubStrip) nearest(col int) (int, bool) {
+	for d := 0; d < len(s.cells); d++ {
+		for _, c := range []int{col - d, col + d} {
+			if c >= 0 && c < len(s.cells) && s.cells[c].first >= 0 {
+				return s.cells[c].first, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// tickSteps are the calendar intervals date labels can be spaced by.
+var tickSteps = []struct {
+	hours, days, months int
+	layout              string
+}{
+	{hours: 1, layout: "15:04"},
+	{hours: 3, layout: "15:04"},
+	{hours: 6, layout: "15:04"},
+	{days: 1, layout: "Jan 02"},
+	{days: 7, layout: "Jan 02"},
+	{months: 1, layout: "Jan 06"},
+	{months: 3, layout: "Jan 06"},
+	{months: 12, layout: "2006"},
+	{months: 60, layout: "2006"},
+}
+
+// renderTicks draws date labels under the strip, aligned to calendar
+// boundaries and spaced so they do not overlap.
+func (s *scrubStrip) renderTicks() string {
+	width := len(s.cells)
+	span := s.end.Sub(s.start)
+
+	step := tickSteps[len(tickSteps)-1]
+	for _, st := range tickSteps {
+		approx := time.Duration(st.hours)*time.Hour +
+			time.Duration(st.days)*24*time.Hour +
+			time.Duration(st.months)*30*24*time.Hour
+		labelW := len(st.layout) + 2
+		if int(span/approx)*labelW <= width {
+			step = st
+			break
+		}
+	}
+
+	// First boundary at or after start.
+	t := s.start
+	switch {
+	case step.months > 0:
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		if step.months >= 12 {
+			t = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+		}
+	case step.days > 0:
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	default:
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	}
+
+	line := []rune(strings.Repeat(" ", width))
+	next := 0 // first free column
+	for ; !t.After(s.end); t = t.Add(time.Duration(step.hours)*time.Hour).AddDate(0, step.months, step.days) {
+		col, ok := s.column(t)
+		if !ok || col < next {
+			continue
+		}
+		label := []rune("╵" + t.Format(step.layout))
+		if col+len(label) > width {
+			break
+		}
+		copy(line[col:], label)
+		next = col + len(label) + 1
+	}
+	return lipgloss.NewStyle().Foreground(ColorSubtle).Render(string(line))
+}
+
+// rangeColumns returns the columns covered by the A-B play range and the
+// columns of the in and out marks (-1 when off the strip or unset). ok is
+// false when no mark is set or the range lies outside the strip.
+func (s *scrubStrip) rangeColumns(m *Model) (lo, hi, in, out int, ok bool) {
+	in, out = -1, -1
+	if !m.hasRange() {
+		return 0, 0, in, out, false
+	}
+	ac := m.activeCommits()
+	first, last := m.playRange()
+	from, to := ac[first].Timestamp, ac[last].Timestamp
+	if from.After(to) {
+		from, to = to, from
+	}
+	if to.Before(s.start) || from.After(s.end) {
+		return 0, 0, in, out, false
+	}
+	lo, hi = 0, len(s.cells)-1
+	if col, ok := s.column(from); ok {
+		lo = col
+	}
+	if col, ok := s.column(to); ok {
+		hi = col
+	}
+	if i, ok := m.indexOf(m.markIn); ok {
+		if col, ok := s.column(ac[i].Timestamp); ok {
+			in = col
+		}
+	}
+	if i, ok := m.indexOf(m.markOut); ok {
+		if col, ok := s.column(ac[i].Timestamp); ok {
+			out = col
+		}
+	}
+	return lo, hi, in, out, true
+}
+
+// render draws the strip with the playhead at column head (-1 for none) and
+// a hover marker at column hover. The A-B play range is shaded, with its
+// marks drawn as brackets, and bookmarks and tags are flagged.
+func (s *scrubStrip) render(m *Model, head, hover int) string {
+	lo, hi, in, out, ranged := s.rangeColumns(m)
+
+	var sb strings.Builder
+	for x, cell := range s.cells {
+		played := head >= 0 && x <= head
+		var glyph string
+		var style lipgloss.Style
+		switch {
+		case x == head:
+			glyph, style = "┃", lipgloss.NewStyle().Foreground(ColorText).Bold(true)
+		case x == hover:
+			glyph, style = "╋", lipgloss.NewStyle().Foreground(ColorText).Bold(true)
+		case x == in:
+			glyph, style = "[", lipgloss.NewStyle().Foreground(ColorRenamed).Bold(true)
+		case x == out:
+			glyph, style = "]", lipgloss.NewStyle().Foreground(ColorRenamed).Bold(true)
+		case cell.bookmarked:
+			glyph, style = "⚑", lipgloss.NewStyle().Foreground(ColorDeleted).Bold(true)
+		case cell.tagged:
+			glyph, style = "◆", lipgloss.NewStyle().Foreground(ColorModified).Bold(true)
+		case cell.count == 0:
+			glyph, style = "─", lipgloss.NewStyle().Foreground(ColorDim)
+		default:
+			level := int(math.Ceil(math.Sqrt(float64(cell.count)/float64(s.max))*float64(len(densityGlyphs)))) - 1
+			level = max(0, min(level, len(densityGlyphs)-1))
+			glyph, style = densityGlyphs[level], lipgloss.NewStyle().Foreground(ColorMuted)
+			if played {
+				style = lipgloss.NewStyle().Foreground(ColorAccent)
+			}
+			if m.heatMode == HeatAuthor {
+				if a := m.registry.Get(cell.dominant); a != nil {
+					style = lipgloss.NewStyle().Foreground(a.Color).Faint(!played)
+				}
+			}
+			if cell.kept == 0 {
+				// Everything here is dropped by the director's cut.
+				style = lipgloss.NewStyle().Foreground(ColorDim)
+			}
+		}
+		if ranged && x >= lo && x <= hi {
+			style = style.Background(ColorSurface)
+		}
+		sb.WriteString(style.Render(glyph))
+	}
+	return sb.String()
+}
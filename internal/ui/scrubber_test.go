@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/meetsoni15/gitcinema/internal/bookmarks"
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+func TestTimelineStripIsCachedUntilItsInputsChange(t *testing.T) {
+	m := New(".", "", 0)
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.commits = []git.Commit{{Hash: "a", Timestamp: t0}, {Hash: "b", Timestamp: t0.Add(time.Hour)}}
+	store, err := bookmarks.Open(filepath.Join(t.TempDir(), "bookmarks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetBookmarks(store)
+
+	s := timelineStrip(&m, 10)
+	if timelineStrip(&m, 10) != s {
+		t.Fatal("strip rebuilt with nothing changed")
+	}
+	if timelineStrip(&m, 12) == s {
+		t.Fatal("strip reused at another width")
+	}
+
+	s = timelineStrip(&m, 10)
+	if err := store.Set(bookmarks.Bookmark{Hash: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if s2 := timelineStrip(&m, 10); s2 == s || !s2.cells[len(s2.cells)-1].bookmarked {
+		t.Fatal("strip not rebuilt after bookmarking a commit")
+	}
+
+	s = timelineStrip(&m, 10)
+	m.filteredCommits = m.commits[1:]
+	if timelineStrip(&m, 10) == s {
+		t.Fatal("strip reused after filtering")
+	}
+}
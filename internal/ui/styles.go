@@ -1,92 +1,258 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
 
-// ── Base Colors (Tokyo Night) ───────────────────────────────────────────────
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ── Themes ──────────────────────────────────────────────────────────────────
+
+// Theme is a set of colors for every part of the UI. Colors are given for
+// true-color, 256-color and 16-color terminals, so each degrades to a chosen
+// ANSI color rather than the nearest match.
+type Theme struct {
+	Bg, Surface, Border, Text, Subtle lipgloss.TerminalColor
+	Accent, Selected, Dim, Muted      lipgloss.TerminalColor
+	Added, Modified, Deleted, Renamed lipgloss.TerminalColor
+	Hash                              lipgloss.TerminalColor
+	Bar                               lipgloss.TerminalColor // header, timeline and status bars, selected rows
+
+	Mono bool // no colors at all: selection is reversed and the active pane drawn thick
+}
+
+// tc is a theme color: hex for true-color and 256-color terminals (which
+// lipgloss maps to the nearest of the 256), and an ANSI number for 16 colors.
+func tc(hex, ansi string) lipgloss.TerminalColor {
+	return lipgloss.CompleteColor{TrueColor: hex, ANSI256: hex, ANSI: ansi}
+}
+
+var themes = map[string]Theme{
+	// Tokyo Night
+	"dark": {
+		Bg: tc("#1a1b26", "0"), Surface: tc("#24283b", "8"), Border: tc("#414868", "8"),
+		Text: tc("#c0caf5", "7"), Subtle: tc("#565f89", "8"),
+		Accent: tc("#7aa2f7", "12"), Selected: tc("#7aa2f7", "12"),
+		Dim: tc("#414868", "8"), Muted: tc("#565f89", "8"),
+		Added: tc("#9ece6a", "10"), Modified: tc("#e0af68", "11"),
+		Deleted: tc("#f7768e", "9"), Renamed: tc("#2ac3de", "14"),
+		Hash: tc("#bb9af7", "13"), Bar: tc("#1e2030", "0"),
+	},
+	// Tokyo Night Day
+	"light": {
+		Bg: tc("#e1e2e7", "15"), Surface: tc("#c4c8da", "7"), Border: tc("#a8aecb", "7"),
+		Text: tc("#3760bf", "0"), Subtle: tc("#6172b0", "8"),
+		Accent: tc("#2e7de9", "4"), Selected: tc("#2e7de9", "4"),
+		Dim: tc("#a8aecb", "7"), Muted: tc("#8990b3", "8"),
+		Added: tc("#587539", "2"), Modified: tc("#8c6c3e", "3"),
+		Deleted: tc("#f52a65", "1"), Renamed: tc("#007197", "6"),
+		Hash: tc("#9854f1", "5"), Bar: tc("#d0d5e3", "7"),
+	},
+	"high-contrast": {
+		Bg: tc("#000000", "0"), Surface: tc("#3a3a3a", "8"), Border: tc("#ffffff", "15"),
+		Text: tc("#ffffff", "15"), Subtle: tc("#d0d0d0", "7"),
+		Accent: tc("#ffff00", "11"), Selected: tc("#00ffff", "14"),
+		Dim: tc("#808080", "8"), Muted: tc("#c0c0c0", "7"),
+		Added: tc("#00ff00", "10"), Modified: tc("#ffff00", "11"),
+		Deleted: tc("#ff5f5f", "9"), Renamed: tc("#00ffff", "14"),
+		Hash: tc("#ff87ff", "13"), Bar: tc("#000000", "0"),
+	},
+	// Solarized dark
+	"solarized": {
+		Bg: tc("#002b36", "0"), Surface: tc("#073642", "0"), Border: tc("#586e75", "8"),
+		Text: tc("#93a1a1", "7"), Subtle: tc("#657b83", "8"),
+		Accent: tc("#268bd2", "4"), Selected: tc("#2aa198", "6"),
+		Dim: tc("#586e75", "8"), Muted: tc("#657b83", "8"),
+		Added: tc("#859900", "2"), Modified: tc("#b58900", "3"),
+		Deleted: tc("#dc322f", "1"), Renamed: tc("#2aa198", "6"),
+		Hash: tc("#6c71c4", "5"), Bar: tc("#073642", "0"),
+	},
+	"monochrome": {
+		Bg: none, Surface: none, Border: none, Text: none, Subtle: none,
+		Accent: none, Selected: none, Dim: none, Muted: none,
+		Added: none, Modified: none, Deleted: none, Renamed: none,
+		Hash: none, Bar: none, Mono: true,
+	},
+}
+
+var none = lipgloss.NoColor{}
+
+// Themes lists the built-in theme names. "auto" picks dark or light from the
+// terminal's background.
+var Themes = []string{"auto", "dark", "light", "high-contrast", "solarized", "monochrome"}
+
+// ThemeByName returns a built-in theme. Under NO_COLOR every theme is
+// monochrome, so emphasis still shows without color.
+func ThemeByName(name string) (Theme, error) {
+	if !slices.Contains(Themes, name) {
+		return Theme{}, fmt.Errorf("unknown theme %q (available: %s, or a theme file)", name, strings.Join(Themes, ", "))
+	}
+	switch {
+	case os.Getenv("NO_COLOR") != "":
+		name = "monochrome"
+	case name == "auto" && lipgloss.HasDarkBackground():
+		name = "dark"
+	case name == "auto":
+		name = "light"
+	}
+	return themes[name], nil
+}
+
+// WithColors returns the theme with some colors replaced, keyed by the
+// lower-case field name, e.g. {"accent": "#ff9e64"}. Values are hex colors or
+// ANSI numbers.
+func (t Theme) WithColors(colors map[string]string) (Theme, error) {
+	fields := map[string]*lipgloss.TerminalColor{
+		"bg": &t.Bg, "surface": &t.Surface, "border": &t.Border, "text": &t.Text,
+		"subtle": &t.Subtle, "accent": &t.Accent, "selected": &t.Selected, "dim": &t.Dim,
+		"muted": &t.Muted, "added": &t.Added, "modified": &t.Modified, "deleted": &t.Deleted,
+		"renamed": &t.Renamed, "hash": &t.Hash, "bar": &t.Bar,
+	}
+	var bad []string
+	for name, c := range colors {
+		f, ok := fields[name]
+		if !ok {
+			bad = append(bad, name)
+			continue
+		}
+		if !t.Mono {
+			*f = lipgloss.Color(c)
+		}
+	}
+	if bad != nil {
+		slices.Sort(bad)
+		return t, fmt.Errorf("unknown theme colors: %s", strings.Join(bad, ", "))
+	}
+	return t, nil
+}
+
+// ── Base Colors ─────────────────────────────────────────────────────────────
 
 var (
-	ColorBg       = lipgloss.AdaptiveColor{Light: "#f5f5f5", Dark: "#1a1b26"}
-	ColorSurface  = lipgloss.AdaptiveColor{Light: "#e8e8e8", Dark: "#24283b"}
-	ColorBorder   = lipgloss.AdaptiveColor{Light: "#9898a6", Dark: "#414868"}
-	ColorText     = lipgloss.AdaptiveColor{Light: "#1a1b26", Dark: "#c0caf5"}
-	ColorSubtle   = lipgloss.AdaptiveColor{Light: "#6272a4", Dark: "#565f89"}
-	ColorSelected = lipgloss.Color("#7aa2f7")
-	ColorAccent   = lipgloss.Color("#7aa2f7")
-	ColorDim      = lipgloss.Color("#414868")
-	ColorMuted    = lipgloss.Color("#565f89")
+	ColorBg       lipgloss.TerminalColor
+	ColorSurface  lipgloss.TerminalColor
+	ColorBorder   lipgloss.TerminalColor
+	ColorText     lipgloss.TerminalColor
+	ColorSubtle   lipgloss.TerminalColor
+	ColorSelected lipgloss.TerminalColor
+	ColorAccent   lipgloss.TerminalColor
+	ColorDim      lipgloss.TerminalColor
+	ColorMuted    lipgloss.TerminalColor
+	ColorHash     lipgloss.TerminalColor
+	ColorBar      lipgloss.TerminalColor
 )
 
 // ── File Change Colors ──────────────────────────────────────────────────────
 
 var (
-	ColorAdded    = lipgloss.Color("#9ece6a") // green
-	ColorModified = lipgloss.Color("#e0af68") // yellow
-	ColorDeleted  = lipgloss.Color("#f7768e") // red
-	ColorRenamed  = lipgloss.Color("#2ac3de") // cyan
+	ColorAdded    lipgloss.TerminalColor // green
+	ColorModified lipgloss.TerminalColor // yellow
+	ColorDeleted  lipgloss.TerminalColor // red
+	ColorRenamed  lipgloss.TerminalColor // cyan
 )
 
 // ── Pane Styles ──────────────────────────────────────────────────────────────
 
 var (
+	PaneStyle        lipgloss.Style
+	ActivePaneStyle  lipgloss.Style
+	HeaderBarStyle   lipgloss.Style
+	StatusBarStyle   lipgloss.Style
+	TimelineBarStyle lipgloss.Style
+	TitleStyle       lipgloss.Style
+	SubtitleStyle    lipgloss.Style
+	KeyStyle         lipgloss.Style
+	HelpStyle        lipgloss.Style
+	SelectedStyle    lipgloss.Style
+	StatAddStyle     lipgloss.Style
+	StatDelStyle     lipgloss.Style
+	HashStyle        lipgloss.Style
+	DateStyle        lipgloss.Style
+)
+
+func init() {
+	ApplyTheme(themes["dark"])
+}
+
+// ApplyTheme sets the colors and rebuilds the styles of the whole UI.
+func ApplyTheme(t Theme) {
+	ColorBg, ColorSurface, ColorBorder, ColorText, ColorSubtle = t.Bg, t.Surface, t.Border, t.Text, t.Subtle
+	ColorSelected, ColorAccent, ColorDim, ColorMuted = t.Selected, t.Accent, t.Dim, t.Muted
+	ColorHash, ColorBar = t.Hash, t.Bar
+	ColorAdded, ColorModified, ColorDeleted, ColorRenamed = t.Added, t.Modified, t.Deleted, t.Renamed
+
 	PaneStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorBorder).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorder).
+		Padding(0, 1)
 
 	ActivePaneStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorAccent).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorAccent).
+		Padding(0, 1)
+	if t.Mono {
+		ActivePaneStyle = ActivePaneStyle.Border(lipgloss.ThickBorder())
+	}
 
+	// Bars are one row: anything longer is cut rather than wrapped, so the
+	// panes keep their height.
 	HeaderBarStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("#1e2030")).
-			Foreground(ColorText).
-			Padding(0, 2)
+		Background(ColorBar).
+		Foreground(ColorText).
+		Padding(0, 2).
+		MaxHeight(1)
 
 	StatusBarStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("#1e2030")).
-			Foreground(ColorMuted).
-			Padding(0, 1)
+		Background(ColorBar).
+		Foreground(ColorMuted).
+		Padding(0, 1).
+		MaxHeight(1)
 
 	TimelineBarStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("#1e2030")).
-				Foreground(ColorText).
-				Padding(0, 1)
+		Background(ColorBar).
+		Foreground(ColorText).
+		Padding(0, 1).
+		MaxHeight(1)
 
 	TitleStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(ColorAccent).
+		Bold(true).
+		Padding(0, 1)
 
 	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(ColorSubtle).
-			Italic(true)
+		Foreground(ColorSubtle).
+		Italic(true)
 
 	KeyStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+		Foreground(ColorMuted).
+		Faint(t.Mono)
 
 	SelectedStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("#1e2030")).
-			Foreground(ColorSelected).
-			Bold(true)
+		Background(ColorBar).
+		Foreground(ColorSelected).
+		Bold(true).
+		Reverse(t.Mono)
 
 	StatAddStyle = lipgloss.NewStyle().
-			Foreground(ColorAdded).Bold(true)
+		Foreground(ColorAdded).Bold(true)
 
 	StatDelStyle = lipgloss.NewStyle().
-			Foreground(ColorDeleted).Bold(true)
+		Foreground(ColorDeleted).Bold(true)
 
 	HashStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#bb9af7")).
-			Bold(true)
+		Foreground(ColorHash).
+		Bold(true)
 
 	DateStyle = lipgloss.NewStyle().
-			Foreground(ColorSubtle)
-)
+		Foreground(ColorSubtle)
+}
 
 // ── File Change Status Styles ─────────────────────────────────────────────
 
@@ -2,8 +2,8 @@ package ui
 
 import (
 	"fmt"
-	"math"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/meetsoni15/gitcinema/internal/git"
@@ -11,33 +11,34 @@ import (
 
 // renderTimeline renders the bottom timeline scrubber bar.
 func renderTimeline(m *Model) string {
-	if len(m.commits) == 0 {
-		return TimelineBarStyle.Width(m.width).Render("  no commits")
+	if len(m.activeCommits()) == 0 {
+		return TimelineBarStyle.Width(m.width).Render("  no commits") + "\n" +
+			TimelineBarStyle.Width(m.width).Render("") + "\n" +
+			TimelineBarStyle.Width(m.width).Render("")
 	}
 
-	c := m.commits[m.cursor]
-	total := len(m.commits)
+	c := m.currentCommit()
+	total := len(m.activeCommits())
 
-	// ── Progress bar ──────────────────────────────────────────────────────────
-	barWidth := m.width - 20
-	if barWidth < 10 {
-		barWidth = 10
+	// ── Density scrubber ──────────────────────────────────────────────────────
+	_, barWidth := timelineBarSpan(m)
+	strip := timelineStrip(m, barWidth)
+	head, _ := strip.column(c.Timestamp)
+	hover := -1
+	if hc, ok := m.hoverCommit(); ok {
+		hover, _ = strip.column(hc.Timestamp)
 	}
-	filled := int(math.Round(float64(m.cursor) / float64(total-1) * float64(barWidth)))
-	if total == 1 {
-		filled = barWidth
-	}
-
-	bar := lipgloss.NewStyle().Foreground(ColorAccent).Render(strings.Repeat("━", filled)) +
-		lipgloss.NewStyle().Foreground(ColorDim).Render(strings.Repeat("─", barWidth-filled))
+	bar := strip.render(m, head, hover)
 
 	// ── Playback indicator ───────────────────────────────────────────────────
 	playIcon := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).Render("▶")
+	if m.reverse {
+		playIcon = lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).Render("◀")
+	}
 	if m.playing {
-		playIcon = lipgloss.NewStyle().Foreground(lipgloss.Color("#f7768e")).Bold(true).Render("⏸")
+		playIcon = lipgloss.NewStyle().Foreground(ColorDeleted).Bold(true).Render("⏸")
 	}
-	speedStr := lipgloss.NewStyle().Foreground(ColorSubtle).
-		Render(fmt.Sprintf("%.2gx", m.speed))
+	speedStr := lipgloss.NewStyle().Foreground(ColorSubtle).Render(speedLabel(m))
 
 	// ── Position label ───────────────────────────────────────────────────────
 	posLabel := lipgloss.NewStyle().Foreground(ColorMuted).
@@ -53,15 +54,83 @@ func renderTimeline(m *Model) string {
 	dateStr := DateStyle.Render(c.FormattedDate())
 
 	row1 := " " + playIcon + " " + speedStr + "  " + bar
-	row2 := posLabel + authorBadge +
-		HashStyle.Render(c.ShortHash) + "  " +
-		lipgloss.NewStyle().Foreground(ColorText).Render(truncate(c.Subject, m.width-40)) +
+
+	// ── Date ticks ───────────────────────────────────────────────────────────
+	x0, _ := timelineBarSpan(m)
+	zoomLabel := HelpStyle.Render(truncate(" "+m.zoom.String(), x0-1))
+	ticks := zoomLabel + strings.Repeat(" ", max(x0-1-lipgloss.Width(zoomLabel), 0)) + strip.renderTicks()
+
+	// Hovering the bar previews the commit under the pointer instead.
+	if hc, ok := m.hoverCommit(); ok {
+		c = hc
+		posLabel = lipgloss.NewStyle().Foreground(ColorText).Bold(true).
+			Render(fmt.Sprintf(" ⌖ %d/%d ", m.hoverIdx+1, total))
+		authorBadge = ""
+		if a := m.registry.Get(c.Email); a != nil {
+			authorBadge = " " + a.Badge() + " "
+		}
+		dateStr = DateStyle.Render(c.FormattedDate())
+	}
+	tagStr := ""
+	if names := m.tags[c.Hash]; len(names) > 0 {
+		tagStr = lipgloss.NewStyle().Foreground(ColorModified).Bold(true).
+			Render("◆ "+strings.Join(names, ", ")) + "  "
+	}
+	// Real-time playback shows the simulated date instead.
+	if m.realtime && m.hoverIdx < 0 {
+		dateStr = lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).
+			Render("⏱ "+m.clock(time.Now()).Format("Jan 02, 2006 15:04")) +
+			HelpStyle.Render("  "+compressionLabel(compressionPresets[m.compressionIdx]))
+	}
+	if note := bookmarkNote(m, c.Hash); note != "" {
+		tagStr += note + "  "
+	}
+	if r := m.activeCut()[c.Hash]; r != "" {
+		tagStr += HelpStyle.Render("✂ "+r) + "  "
+	}
+	prefix := posLabel + authorBadge + HashStyle.Render(c.ShortHash) + "  " + tagStr
+	subjectW := m.width - lipgloss.Width(prefix) - lipgloss.Width(dateStr) - 6
+	row2 := prefix +
+		lipgloss.NewStyle().Foreground(ColorText).Render(truncate(c.Subject, subjectW)) +
 		"  " + dateStr
 
 	return TimelineBarStyle.Width(m.width).Render(row1) + "\n" +
+		TimelineBarStyle.Width(m.width).Render(ticks) + "\n" +
 		TimelineBarStyle.Width(m.width).Render(row2)
 }
 
+// speedLabel renders the playback speed, e.g. "1x" or "-32x" in reverse,
+// followed by the frames-per-commit setting when it is not one, and marked
+// with a clock in real-time mode and a loop arrow when looping.
+func speedLabel(m *Model) string {
+	label := fmt.Sprintf("%gx", m.speed)
+	if m.reverse {
+		label = "-" + label
+	}
+	if fpc := framesPerCommitPresets[m.fpcIdx]; fpc != 1 && !m.realtime {
+		label += " " + fpcLabel(fpc)
+	}
+	if m.realtime {
+		label += " ⏱"
+	}
+	if m.loop {
+		label += " ⟳"
+	}
+	return label
+}
+
+// timelineBarSpan returns the screen column where the scrubber bar starts and
+// its width. The bar follows the bar padding, play icon and speed label, and
+// narrows as the label grows.
+func timelineBarSpan(m *Model) (x, width int) {
+	x = 1 + 1 + 1 + 1 + lipgloss.Width(speedLabel(m)) + 2
+	width = m.width - x - 12
+	if width < 10 {
+		width = 10
+	}
+	return x, width
+}
+
 // renderLegend renders the top author legend strip.
 func renderLegend(m *Model) string {
 	authors := m.registry.All()
@@ -69,29 +138,65 @@ func renderLegend(m *Model) string {
 		return ""
 	}
 
-	var parts []string
-	for _, a := range authors {
-		parts = append(parts, a.Tag())
-	}
-
 	// Filter indicator
 	filterStr := ""
-	if m.filterAuthor != "" {
+	if label := authorFilterLabel(m); label != "" {
 		filterStr = "  " + lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#f7768e")).
+			Foreground(ColorDeleted).
 			Bold(true).
-			Render("filter: "+m.filterAuthor)
+			Render("filter: "+label)
+	}
+	if q := m.queryFilter; q != nil {
+		label := fmt.Sprintf("query: %s (%d commits)", truncate(q.String(), 30), len(m.filteredCommits))
+		if m.filterPending {
+			label = fmt.Sprintf("query: %s (loading…)", truncate(q.String(), 30))
+		}
+		filterStr += "  " + lipgloss.NewStyle().
+			Foreground(ColorDeleted).
+			Bold(true).
+			Render(label)
+	}
+	if m.funcFilter != nil {
+		filterStr = "  " + lipgloss.NewStyle().
+			Foreground(ColorDeleted).
+			Bold(true).
+			Render(fmt.Sprintf("func: %s (%d commits)", m.funcFilter, len(m.filteredCommits)))
+	} else if m.funcsLoading && m.state != StatePickingFunc {
+		filterStr = "  " + HelpStyle.Render("tracing function history…")
+	}
+	if m.pauseOn {
+		filterStr += "  " + lipgloss.NewStyle().Foreground(ColorModified).Bold(true).Render("⏸ rules")
+	}
+	if m.cutOn {
+		if m.cut == nil {
+			filterStr += "  " + HelpStyle.Render("✂ cutting…")
+		} else {
+			filterStr += "  " + lipgloss.NewStyle().Foreground(ColorModified).Bold(true).
+				Render(fmt.Sprintf("✂ cut %d", cutCount(m)))
+		}
 	}
 
-	legend := strings.Join(parts, "  ")
 	right := HelpStyle.Render(fmt.Sprintf("%d authors", len(authors))) + filterStr
 
-	// Right-align the count
-	pad := m.width - lipgloss.Width(legend) - lipgloss.Width(right) - 6
-	if pad < 1 {
-		pad = 1
+	// As many authors as fit on the one row, then how many more there are.
+	room := m.width - lipgloss.Width(right) - 7
+	var parts []string
+	for i, a := range authors {
+		more := ""
+		if i < len(authors)-1 {
+			more = fmt.Sprintf("  +%d", len(authors)-i-1)
+		}
+		tag := authorTag(m, a)
+		if w := lipgloss.Width(strings.Join(append(parts, tag), "  ") + more); w > room {
+			parts = append(parts, HelpStyle.Render(fmt.Sprintf("+%d", len(authors)-i)))
+			break
+		}
+		parts = append(parts, tag)
 	}
+	legend := strings.Join(parts, "  ")
 
+	// Right-align the count
+	pad := max(m.width-lipgloss.Width(legend)-lipgloss.Width(right)-6, 1)
 	return HeaderBarStyle.Width(m.width).Render(
 		"  " + legend + strings.Repeat(" ", pad) + right,
 	)
@@ -99,17 +204,46 @@ func renderLegend(m *Model) string {
 
 // renderStatusBar renders the bottom keybinding help bar.
 func renderStatusBar(m *Model) string {
+	k := &m.keys
 	bindings := []string{
-		KeyStyle.Render("Space") + HelpStyle.Render(" play/pause"),
-		KeyStyle.Render("j/k") + HelpStyle.Render(" step"),
-		KeyStyle.Render("+/-") + HelpStyle.Render(" speed"),
-		KeyStyle.Render("g/G") + HelpStyle.Render(" first/last"),
-		KeyStyle.Render("f") + HelpStyle.Render(" filter"),
-		KeyStyle.Render("/") + HelpStyle.Render(" search"),
-		KeyStyle.Render("Tab") + HelpStyle.Render(" pane"),
-		KeyStyle.Render("q") + HelpStyle.Render(" quit"),
-	}
-	return StatusBarStyle.Width(m.width).Render("  " + strings.Join(bindings, "  "))
+		shortHelp("play/pause", k.Play),
+		shortHelp("step", k.Next, k.Prev),
+		shortHelp("speed", k.Faster, k.Slower),
+		shortHelp("first/last", k.First, k.Last),
+		shortHelp("filter", k.Filter),
+		shortHelp("search", k.Search),
+		shortHelp("pane", k.SwitchPane),
+		shortHelp("keys", k.Help),
+		shortHelp("quit", k.Quit),
+	}
+	return statusBar(m, statusNote(m), bindings)
+}
+
+// statusNote is the note the status bar leads with: a notice, or why
+// playback paused.
+func statusNote(m *Model) string {
+	if m.notice == "" && m.pausedBy != "" {
+		return "⏸ paused: " + m.pausedBy
+	}
+	return m.notice
+}
+
+// statusBar renders a bar of key bindings, after a highlighted note if there
+// is one, dropping bindings from the end until it fits.
+func statusBar(m *Model, note string, bindings []string) string {
+	parts := []string{}
+	if note != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(ColorModified).Bold(true).Render(truncate(note, m.width-6)))
+	}
+	for _, b := range bindings {
+		if b != "" {
+			parts = append(parts, b)
+		}
+	}
+	for len(parts) > 1 && lipgloss.Width(strings.Join(parts, "  "))+4 > m.width {
+		parts = parts[:len(parts)-1]
+	}
+	return StatusBarStyle.Width(m.width).Render("  " + strings.Join(parts, "  "))
 }
 
 // renderSearchBar renders the search input when in search mode.
@@ -118,20 +252,17 @@ func renderSearchBar(m *Model) string {
 	input := lipgloss.NewStyle().Foreground(ColorText).Render(m.searchQuery)
 	cursor := lipgloss.NewStyle().Foreground(ColorAccent).Render("█")
 	hits := ""
-	if m.searchQuery != "" {
+	switch {
+	case m.searchErr != nil:
+		hits = StatDelStyle.Render("  invalid query")
+	case m.searchPending:
+		hits = HelpStyle.Render("  loading change sizes…")
+	case m.searchQuery != "":
 		hits = HelpStyle.Render(fmt.Sprintf("  %d results", len(m.searchResults)))
 	}
 	return StatusBarStyle.Width(m.width).Render("  " + prompt + " " + input + cursor + hits)
 }
 
-// renderFilterBar renders the author filter input.
-func renderFilterBar(m *Model) string {
-	prompt := lipgloss.NewStyle().Foreground(lipgloss.Color("#e0af68")).Bold(true).Render("filter author:")
-	input := lipgloss.NewStyle().Foreground(ColorText).Render(m.filterQuery)
-	cursor := lipgloss.NewStyle().Foreground(lipgloss.Color("#e0af68")).Render("█")
-	return StatusBarStyle.Width(m.width).Render("  " + prompt + " " + input + cursor)
-}
-
 // truncate truncates a string to maxLen runes, adding "…" if needed.
 func truncate(s string, maxLen int) string {
 	runes := []rune(s)
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/meetsoni15/gitcinema/internal/git"
+)
+
+// LeftView selects what the left pane shows.
+type LeftView int
+
+const (
+	ViewChangedFiles LeftView = iota // files touched by the current commit
+	ViewRepoTree                     // the whole repository at the current commit
+	ViewCommitList                   // neighboring commits around the cursor
+)
+
+// TreeSort selects how siblings in the left pane tree are ordered.
+type TreeSort int
+
+const (
+	SortByPath   TreeSort = iota // directories first, then alphabetical
+	SortByChurn                  // most added+deleted lines first
+	SortByStatus                 // added, modified, renamed, deleted
+)
+
+func (s TreeSort) String() string {
+	switch s {
+	case SortByChurn:
+		return "churn"
+	case SortByStatus:
+		return "status"
+	}
+	return "path"
+}
+
+// treeNode is a directory or file in a rendered file tree.
+type treeNode struct {
+	name     string
+	path     string
+	dir      bool
+	children []*treeNode
+	change   *git.FileChange // set on files touched by the current commit
+	changed  int             // for directories: touched files below this one
+	adds     int             // lines added to this file or below this directory
+	dels     int             // lines deleted from this file or below this directory
+}
+
+// treeRow is one visible line of a flattened tree.
+type treeRow struct {
+	node  *treeNode
+	depth int
+}
+
+// buildTree arranges paths into a directory hierarchy, attaching the matching
+// change to every touched file. Changed paths missing from paths (deleted
+// files) are added so the tree shows them too.
+func buildTree(paths []string, changes []git.FileChange) *treeNode {
+	root := &treeNode{dir: true}
+	index := map[string]*treeNode{"": root}
+
+	var add func(p string) *treeNode
+	add = func(p string) *treeNode {
+		if n, ok := index[p]; ok {
+			return n
+		}
+		parent := add(parentPath(p))
+		parent.dir = true
+		n := &treeNode{name: p[strings.LastIndex(p, "/")+1:], path: p}
+		parent.children = append(parent.children, n)
+		index[p] = n
+		return n
+	}
+
+	for _, p := range paths {
+		add(p)
+	}
+	for i := range changes {
+		fc := &changes[i]
+		n := add(fc.Path)
+		n.change = fc
+		n.adds, n.dels = fc.Additions, fc.Deletions
+		for p := fc.Path; p != ""; {
+			p = parentPath(p)
+			index[p].changed++
+			index[p].adds += fc.Additions
+			index[p].dels += fc.Deletions
+		}
+	}
+	return root
+}
+
+// compactTree merges chains of directories that hold nothing but a single
+// subdirectory into one node, e.g. "internal/ui".
+func compactTree(n *treeNode) {
+	for i, c := range n.children {
+		for c.dir && len(c.children) == 1 && c.children[0].dir {
+			only := c.children[0]
+			only.name = c.name + "/" + only.name
+			c = only
+		}
+		n.children[i] = c
+		compactTree(c)
+	}
+}
+
+// sortTree orders the children of every directory below n.
+func sortTree(n *treeNode, mode TreeSort) {
+	sort.SliceStable(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		switch mode {
+		case SortByChurn:
+			if ca, cb := a.adds+a.dels, b.adds+b.dels; ca != cb {
+				return ca > cb
+			}
+		case SortByStatus:
+			if a.dir == b.dir && !a.dir && a.change != nil && b.change != nil &&
+				statusRank(a.change.Status) != statusRank(b.change.Status) {
+				return statusRank(a.change.Status) < statusRank(b.change.Status)
+			}
+		}
+		if a.dir != b.dir {
+			return a.dir
+		}
+		return a.name < b.name
+	})
+	for _, c := range n.children {
+		if c.dir {
+			sortTree(c, mode)
+		}
+	}
+}
+
+// statusRank orders change statuses: added, modified, renamed, deleted.
+func statusRank(s git.ChangeStatus) int {
+	switch s {
+	case git.StatusAdded:
+		return 0
+	case git.StatusModified:
+		return 1
+	case git.StatusRenamed:
+		return 2
+	case git.StatusDeleted:
+		return 3
+	}
+	return 4
+}
+
+// isExpanded reports whether a directory shows its children. Directories
+// holding changes start expanded, others collapsed, until the user toggles them.
+func isExpanded(n *treeNode, expanded map[string]bool) bool {
+	if e, ok := expanded[n.path]; ok {
+		return e
+	}
+	return n.changed > 0
+}
+
+// rows flattens the visible part of the tree below n.
+func (n *treeNode) rows(expanded map[string]bool) []treeRow {
+	var out []treeRow
+	var walk func(n *treeNode, depth int)
+	walk = func(n *treeNode, depth int) {
+		for _, c := range n.children {
+			out = append(out, treeRow{node: c, depth: depth})
+			if c.dir && isExpanded(c, expanded) {
+				walk(c, depth+1)
+			}
+		}
+	}
+	walk(n, 0)
+	return out
+}
+
+// parentPath returns the directory containing p, or "" at the top level.
+func parentPath(p string) string {
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		return p[:i]
+	}
+	return ""
+}
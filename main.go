@@ -1,13 +1,18 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/meetsoni15/gitcinema/internal/bookmarks"
+	"github.com/meetsoni15/gitcinema/internal/config"
 	"github.com/meetsoni15/gitcinema/internal/git"
+	"github.com/meetsoni15/gitcinema/internal/query"
 	"github.com/meetsoni15/gitcinema/internal/ui"
 )
 
@@ -27,13 +32,18 @@ func main() {
 	args := os.Args[1:]
 
 	// ── Flags ────────────────────────────────────────────────────────────────
+	// Settings that a config file can also hold are collected into flags and
+	// applied over the config files, so the command line always wins.
 	var (
-		root     = "."
-		branch   = ""
-		maxCount = 500
-		author   = ""
+		root       = "."
+		branch     = ""
+		author     = ""
+		filter     = ""
+		configPath = ""
+		exportBM   = ""
+		importBM   = ""
+		flags      config.Config
 	)
-
 	positionals := []string{}
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
@@ -51,13 +61,96 @@ func main() {
 		case "--max":
 			if i+1 < len(args) {
 				i++
-				maxCount, _ = strconv.Atoi(args[i])
+				n, _ := strconv.Atoi(args[i])
+				flags.Defaults.Max = &n
+			}
+		case "--speed":
+			if i+1 < len(args) {
+				i++
+				speed, _ := strconv.ParseFloat(args[i], 64)
+				flags.Defaults.Speed = &speed
+			}
+		case "--theme":
+			if i+1 < len(args) {
+				i++
+				flags.Theme = args[i]
+			}
+		case "--palette":
+			if i+1 < len(args) {
+				i++
+				flags.Authors.Palette = args[i]
+			}
+		case "--config":
+			if i+1 < len(args) {
+				i++
+				configPath = args[i]
+			}
+		case "--cut":
+			flags.Defaults.Cut = ptr(true)
+		case "--skip-glob":
+			if i+1 < len(args) {
+				i++
+				flags.Cut.Globs = append(flags.Cut.Globs, args[i])
+			}
+		case "--skip-under":
+			if i+1 < len(args) {
+				i++
+				n, _ := strconv.Atoi(args[i])
+				flags.Cut.MaxLines = &n
+			}
+		case "--skip-msg":
+			if i+1 < len(args) {
+				i++
+				flags.Cut.Messages = append(flags.Cut.Messages, args[i])
+			}
+		case "--keep-merges":
+			flags.Cut.Merges = ptr(false)
+		case "--keep-bots":
+			flags.Cut.Bots = ptr(false)
+		case "--fast-forward":
+			flags.Cut.FastForward = ptr(true)
+		case "--pause":
+			flags.Defaults.Pause = ptr(true)
+		case "--pause-path":
+			if i+1 < len(args) {
+				i++
+				flags.Pause.Paths = append(flags.Pause.Paths, args[i])
+			}
+		case "--pause-over":
+			if i+1 < len(args) {
+				i++
+				n, _ := strconv.Atoi(args[i])
+				flags.Pause.MinLines = &n
+			}
+		case "--pause-msg":
+			if i+1 < len(args) {
+				i++
+				flags.Pause.Messages = append(flags.Pause.Messages, args[i])
+			}
+		case "--no-pause-tags":
+			flags.Pause.Tags = ptr(false)
+		case "--no-pause-authors":
+			flags.Pause.NewAuthors = ptr(false)
+		case "--export-bookmarks":
+			if i+1 < len(args) {
+				i++
+				exportBM = args[i]
+			}
+		case "--import-bookmarks":
+			if i+1 < len(args) {
+				i++
+				importBM = args[i]
 			}
 		case "--author":
 			if i+1 < len(args) {
 				i++
 				author = args[i]
 			}
+		case "--filter":
+			if i+1 < len(args) {
+				i++
+				filter = args[i]
+			}
 		default:
 			if len(args[i]) > 0 && args[i][0] != '-' {
 				positionals = append(positionals, args[i])
@@ -85,20 +178,111 @@ func main() {
 		branch = git.DefaultBranch(absRoot)
 	}
 
-	// ── Launch ────────────────────────────────────────────────────────────────
+	// ── Config ───────────────────────────────────────────────────────────────
+	cfg, err := loadConfig(absRoot, configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: %v\n", err)
+		os.Exit(1)
+	}
+	cfg.Overlay(&flags)
+	if flags.Authors.Palette != "" {
+		cfg.Authors.Colors = nil // the flag wins over colors from a file
+	}
+	maxCount := 500
+	if cfg.Defaults.Max != nil {
+		maxCount = *cfg.Defaults.Max
+	}
 	m := ui.New(absRoot, branch, maxCount)
-	if author != "" {
-		// Pre-set author filter (passed as CLI flag)
-		_ = author // model init will handle it in a future enhancement
+	if err := errors.Join(cfg.Validate(), m.Configure(cfg)); err != nil {
+		configError(err)
+	}
+
+	// ── Bookmarks and saved filters ──────────────────────────────────────────
+	var store *bookmarks.Store
+	var saved *query.Saved
+	if gitDir, err := git.GitDir(absRoot); err == nil {
+		store, err = bookmarks.Open(bookmarks.Path(gitDir))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		saved, err = query.OpenSaved(query.SavedPath(gitDir))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if importBM != "" || exportBM != "" {
+		if store == nil {
+			fmt.Fprintln(os.Stderr, "Error: cannot locate the repository's git directory for bookmarks")
+			os.Exit(1)
+		}
+	}
+	if importBM != "" {
+		n, err := store.Import(importBM)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Imported %d bookmark(s) from %s\n", n, importBM)
+	}
+	if exportBM != "" {
+		if err := store.Export(exportBM); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Exported %d bookmark(s) to %s\n", store.Len(), exportBM)
+		return
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	// ── Launch ────────────────────────────────────────────────────────────────
+	m.SetBookmarks(store)
+	m.SetSavedFilters(saved)
+	m.SetAuthorFilter(author)
+	if filter != "" {
+		if err := m.SetQueryFilter(filter); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseAllMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// loadConfig reads the user config file, or the one given with --config,
+// followed by the repository's override file.
+func loadConfig(dir, userPath string) (*config.Config, error) {
+	if userPath == "" {
+		if p, err := config.UserPath(); err == nil {
+			userPath = p
+		}
+	} else if _, err := os.Stat(userPath); err != nil {
+		return nil, err // a file named with --config must exist
+	}
+	paths := []string{userPath}
+	if top, err := git.TopLevel(dir); err == nil {
+		paths = append(paths, filepath.Join(top, config.RepoFile))
+	}
+	return config.Load(paths...)
+}
+
+// configError lists every problem with the config and exits.
+func configError(err error) {
+	fmt.Fprintln(os.Stderr, "Error: invalid config:")
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintln(os.Stderr, "  "+line)
+	}
+	os.Exit(1)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
 func isDir(path string) bool {
 	info, err := os.Stat(path)
 	return err == nil && info.IsDir()
@@ -115,21 +299,53 @@ func printHelp() {
 	fmt.Println()
 	fmt.Println("FLAGS:")
 	fmt.Println("  -b, --branch string   Branch to walk (default: current branch)")
-	fmt.Println("  --max int             Max commits to load (default: 500)")
-	fmt.Println("  --author string       Pre-filter by author name")
+	fmt.Println("  --max int             Max commits to load, 0 for all (default: 500)")
+	fmt.Println("  --author string       Start filtered to authors whose name or email contains this")
+	fmt.Println("  --filter query        Start filtered by a query, e.g. \"path:api/** -merge\"")
+	fmt.Println("  --speed float         Initial playback speed (default: 1)")
+	fmt.Println("  --config file         Config file to use instead of the user config")
+	fmt.Println("  --theme name          Color theme: auto, dark, light, high-contrast, solarized,")
+	fmt.Println("                        monochrome, or a theme file (default: auto)")
+	fmt.Println("  --palette name        Author colors: default, or colorblind-safe okabe-ito,")
+	fmt.Println("                        tol-bright, tol-muted")
+	fmt.Println("  --cut                 Start with the director's cut on (toggle with d)")
+	fmt.Println("  --skip-glob glob      Cut frames touching only matching files, repeatable")
+	fmt.Println("                        (default: lockfiles such as go.sum, package-lock.json)")
+	fmt.Println("  --skip-under int      Cut frames changing fewer lines than this")
+	fmt.Println("  --skip-msg regexp     Cut frames whose subject matches, repeatable")
+	fmt.Println("  --keep-merges         Do not cut merge commits")
+	fmt.Println("  --keep-bots           Do not cut commits by bot authors")
+	fmt.Println("  --fast-forward        Flash cut frames briefly instead of skipping them")
+	fmt.Println("  --pause               Start with pause-on-event rules on (toggle with p)")
+	fmt.Println("  --pause-path path     Pause on commits touching a file, directory or glob,")
+	fmt.Println("                        repeatable")
+	fmt.Println("  --pause-over int      Pause on commits changing at least this many lines")
+	fmt.Println("  --pause-msg regexp    Pause on commits whose subject matches, repeatable")
+	fmt.Println("  --no-pause-tags       Do not pause on tagged commits")
+	fmt.Println("  --no-pause-authors    Do not pause on an author's first commit")
+	fmt.Println("  --export-bookmarks file  Write the repository's bookmarks to file and exit")
+	fmt.Println("  --import-bookmarks file  Merge bookmarks from an exported file, then start")
 	fmt.Println("  -v, --version         Show version")
 	fmt.Println("  -h, --help            Show this help")
 	fmt.Println()
-	fmt.Println("KEYBINDINGS:")
-	fmt.Println("  Space        Play / Pause")
-	fmt.Println("  j / ↓        Next commit")
-	fmt.Println("  k / ↑        Previous commit")
-	fmt.Println("  g            First commit")
-	fmt.Println("  G            Last commit")
-	fmt.Println("  + / -        Speed up / slow down")
-	fmt.Println("  /            Search commit messages")
-	fmt.Println("  f            Filter by author")
-	fmt.Println("  Tab          Switch pane focus")
-	fmt.Println("  Esc          Clear filter / search")
-	fmt.Println("  q / Ctrl+C   Quit")
+	fmt.Println("KEYBINDINGS (defaults; press ? for the keys in effect after remapping):")
+	for _, line := range ui.DefaultKeyHelp() {
+		fmt.Println("  " + line)
+	}
+	fmt.Println()
+	fmt.Println("CONFIG:")
+	fmt.Println("  Defaults, rules, keys, theme, author colors, layout and named filters are")
+	fmt.Println("  read from gitcinema/config.json in the user config directory, then")
+	fmt.Println("  .gitcinema.json in the repository root. Flags override both.")
+	fmt.Println()
+	fmt.Println("QUERIES:")
+	fmt.Println("  Search and filter take terms that must all match; -term negates, OR separates")
+	fmt.Println("  alternatives, and commas separate alternative values:")
+	fmt.Println("    author:alice path:internal/** after:2024-01 before:30d size:>200 files:<=3")
+	fmt.Println("    type:feat msg:text hash:abc1 merge bot @name")
+	fmt.Println("  Name filters in the config's \"filters\" or with :save NAME, then use @NAME.")
+	fmt.Println()
+	fmt.Println("MOUSE:")
+	fmt.Println("  Click / drag the timeline to seek, hover it to preview a commit,")
+	fmt.Println("  click tree rows or commits to select, wheel to scroll the pane under the pointer")
 }